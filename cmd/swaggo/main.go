@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
@@ -50,9 +51,19 @@ const swaggerUIHTML = `<!DOCTYPE html>
 </html>`
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "routes":
+			runRoutes(os.Args[2:])
+			return
+		case "verify-routes":
+			runVerifyRoutes(os.Args[2:])
+			return
+		}
+	}
+
 	var (
-		dir         string
-		entry       string
+		src         sourceOptions
 		output      string
 		format      string
 		title       string
@@ -63,15 +74,13 @@ func main() {
 		showVersion bool
 		quiet       bool
 		generateUI  bool
-		exclude     string
-		parseVendor bool
-		parseDeps   bool
+		diagFormat  string
+		strict      bool
+		sourceExt   bool
+		tokenURL    string
 	)
 
-	flag.StringVar(&dir, "dir", ".", "")
-	flag.StringVar(&dir, "d", ".", "")
-	flag.StringVar(&entry, "entry", "", "")
-	flag.StringVar(&entry, "e", "", "")
+	src.register(flag.CommandLine)
 	flag.StringVar(&output, "output", "docs", "")
 	flag.StringVar(&output, "o", "docs", "")
 	flag.StringVar(&format, "format", "both", "")
@@ -85,16 +94,19 @@ func main() {
 	flag.BoolVar(&quiet, "quiet", false, "")
 	flag.BoolVar(&quiet, "q", false, "")
 	flag.BoolVar(&generateUI, "ui", true, "")
-	flag.StringVar(&exclude, "exclude", "", "")
-	flag.StringVar(&exclude, "x", "", "")
-	flag.BoolVar(&parseVendor, "parse-vendor", false, "")
-	flag.BoolVar(&parseDeps, "parse-deps", false, "")
+	flag.StringVar(&diagFormat, "diagnostics", "human", "")
+	flag.BoolVar(&strict, "strict", false, "")
+	flag.BoolVar(&sourceExt, "x-source", false, "")
+	flag.StringVar(&tokenURL, "oauth2-token-url", "", "")
 
 	flag.Usage = func() {
 		fmt.Fprint(os.Stderr, `swaggo - Generate OpenAPI docs from Gin handlers
 
 Usage:
   swaggo [flags]
+  swaggo routes [flags]     List discovered routes (see swaggo routes -h)
+  swaggo verify-routes [flags] <file>
+                            Compare static routes with a runtime route dump (see swaggo verify-routes -h)
 
 Examples:
   swaggo -d ./myproject -t "My API"
@@ -102,9 +114,7 @@ Examples:
   swaggo -d . -x test,mock
 
 Flags:
-  -d, --dir <path>          Project root directory (default ".")
-  -e, --entry <file>        Entry file for import tracing (e.g. cmd/api/main.go)
-  -o, --output <path>       Output directory (default "docs")
+`+sourceFlagsUsage+`  -o, --output <path>       Output directory (default "docs")
   -t, --title <string>      API title (default "API Documentation")
       --desc <string>       API description
       --api-version <ver>   API version (default "1.0.0")
@@ -112,9 +122,12 @@ Flags:
       --base-path <path>    API base path (default "/")
       --format <fmt>        Output format: json, yaml, both (default "both")
       --ui                  Generate Swagger UI HTML (default true)
-  -x, --exclude <dirs>      Directories to exclude (comma separated)
-      --parse-vendor        Parse vendor directory
-      --parse-deps          Parse external dependencies
+      --oauth2-token-url <url>
+                            Token URL of the oauth2 scheme inferred from scope middleware
+                            (without it, scopes are emitted as x-scopes)
+      --x-source            Annotate operations, parameters, bodies and responses with x-source: file:line
+      --strict              Fail on parse errors, unresolved handlers or dangling schema refs
+      --diagnostics <fmt>   Diagnostics output: human, json (to stderr) or none (default "human")
   -q, --quiet               Quiet mode
   -v                        Show version
 
@@ -137,6 +150,13 @@ Entry Mode:
 		}
 	}
 
+	switch diagFormat {
+	case "human", "json", "none":
+	default:
+		fmt.Fprintf(os.Stderr, "Invalid --diagnostics format: %s\n", diagFormat)
+		os.Exit(1)
+	}
+
 	log("swaggo %s\n", version)
 	log("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
 
@@ -150,50 +170,31 @@ Entry Mode:
 		WithDescription(description).
 		WithVersion(apiVersion).
 		WithHost(host).
-		WithBasePath(basePath)
+		WithBasePath(basePath).
+		WithOAuth2TokenURL(tokenURL)
 
-	if exclude != "" {
-		excludeDirs := strings.Split(exclude, ",")
-		for i := range excludeDirs {
-			excludeDirs[i] = strings.TrimSpace(excludeDirs[i])
-		}
-		gen.WithExclude(excludeDirs...)
+	if err := src.configure(gen); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
+	gen.SetStrict(strict)
+	gen.SetSourceExtensions(sourceExt)
 
-	gen.SetParseVendor(parseVendor)
-	gen.SetParseDependency(parseDeps)
-
-	absDir, _ := filepath.Abs(dir)
-
-	if entry != "" {
-		entryPath := filepath.Join(absDir, entry)
-		if _, err := os.Stat(entryPath); os.IsNotExist(err) {
-			fmt.Fprintf(os.Stderr, "Entry file not found: %s\n", entryPath)
-			os.Exit(1)
-		}
-		log("Entry: %s\n", entry)
-		log("Root:  %s\n", absDir)
-
-		if err := gen.ParseFromEntry(entryPath, absDir); err != nil {
-			fmt.Fprintf(os.Stderr, "Parse error: %v\n", err)
-			os.Exit(1)
-		}
-	} else {
-		log("Parsing: %s\n", absDir)
-
-		if err := gen.ParseSource(dir); err != nil {
-			fmt.Fprintf(os.Stderr, "Parse error: %v\n", err)
-			os.Exit(1)
-		}
+	absDir, err := src.parse(gen, log)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Parse error: %v\n", err)
+		os.Exit(1)
 	}
 
 	stats := gen.Stats()
 	log("Found %d routes\n", stats.Routes)
 	log("Found %d handlers\n", stats.Handlers)
 	log("Found %d type definitions\n", stats.Types)
-	log("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
 
+	// dangling $ref 在產生 spec 時才會檢查，diagnostics 於 Generate 之後輸出
 	spec, err := gen.Generate()
+	printDiagnostics(diagFormat, gen.Diagnostics(), log)
+	log("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Generate error: %v\n", err)
 		os.Exit(1)
@@ -244,22 +245,41 @@ Entry Mode:
 
 	if !quiet && len(spec.Paths) > 0 {
 		log("\nEndpoints:\n")
-		for path, item := range spec.Paths {
-			if item.Get != nil {
-				log("  GET    %s\n", path)
-			}
-			if item.Post != nil {
-				log("  POST   %s\n", path)
-			}
-			if item.Put != nil {
-				log("  PUT    %s\n", path)
-			}
-			if item.Delete != nil {
-				log("  DELETE %s\n", path)
-			}
-			if item.Patch != nil {
-				log("  PATCH  %s\n", path)
-			}
+		routes := sortRoutes(gen.Routes(), "path")
+		if err := writeRouteTable(os.Stdout, routes, absDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Write routes error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// printDiagnostics 以 human（跟隨一般輸出）或 json（寫到 stderr）格式輸出 diagnostics
+func printDiagnostics(format string, diags []swaggo.Diagnostic, log func(string, ...any)) {
+	switch format {
+	case "human":
+		for _, d := range diags {
+			log("%s\n", d)
+		}
+	case "json":
+		if diags == nil {
+			diags = []swaggo.Diagnostic{}
+		}
+		data, err := json.MarshalIndent(diags, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Diagnostics serialize error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "%s\n", data)
+	}
+}
+
+// splitList 拆解逗號分隔的 flag 值，忽略空白項目
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
 		}
 	}
+	return items
 }
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/miyago9267/swaggo/pkg/swaggo"
+)
+
+// sourceFlagsUsage 解析原始碼相關 flag 的說明，產生文件與子命令共用
+const sourceFlagsUsage = `  -d, --dir <path>          Project root directory (default ".")
+  -e, --entry <file>        Entry file for import tracing (e.g. cmd/api/main.go)
+  -x, --exclude <dirs>      Directories to exclude (comma separated)
+      --parse-vendor        Parse vendor directory
+      --parse-deps          Parse DTO types from external modules (replace, vendor, module cache)
+      --prune-unreachable   Drop routes whose registration is not reachable from main
+      --role-middleware <f> Extra middleware whose string args are roles (comma separated)
+      --scope-middleware <f>
+                            Extra middleware whose string args are OAuth2 scopes (comma separated)
+      --third-party <mode>  Routes registered by or mounting known third-party packages
+                            (pprof, metrics, healthcheck, swagger UI): document, internal or exclude
+                            (default "document")
+      --tags <tags>         Build tags for evaluating //go:build constraints (comma separated)
+      --goos <os>           Target GOOS for build constraints (default: current)
+      --goarch <arch>       Target GOARCH for build constraints (default: current)
+      --include-condition <patterns>
+                            Keep conditional routes only when a guarding condition matches
+                            (comma separated, * wildcard, e.g. "cfg.Features.*")
+      --exclude-condition <patterns>
+                            Drop routes whose guarding condition matches
+                            (comma separated, * wildcard, e.g. "gin.Mode() == gin.DebugMode")
+`
+
+// sourceOptions 解析原始碼相關的 flag
+type sourceOptions struct {
+	dir         string
+	entry       string
+	exclude     string
+	parseVendor bool
+	parseDeps   bool
+	prune       bool
+	roleFuncs   string
+	scopeFuncs  string
+	thirdParty  string
+	buildTags   string
+	goos        string
+	goarch      string
+	includeCond string
+	excludeCond string
+}
+
+func (o *sourceOptions) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.dir, "dir", ".", "")
+	fs.StringVar(&o.dir, "d", ".", "")
+	fs.StringVar(&o.entry, "entry", "", "")
+	fs.StringVar(&o.entry, "e", "", "")
+	fs.StringVar(&o.exclude, "exclude", "", "")
+	fs.StringVar(&o.exclude, "x", "", "")
+	fs.BoolVar(&o.parseVendor, "parse-vendor", false, "")
+	fs.BoolVar(&o.parseDeps, "parse-deps", false, "")
+	fs.BoolVar(&o.prune, "prune-unreachable", false, "")
+	fs.StringVar(&o.roleFuncs, "role-middleware", "", "")
+	fs.StringVar(&o.scopeFuncs, "scope-middleware", "", "")
+	fs.StringVar(&o.thirdParty, "third-party", swaggo.ThirdPartyDocument, "")
+	fs.StringVar(&o.buildTags, "tags", "", "")
+	fs.StringVar(&o.goos, "goos", "", "")
+	fs.StringVar(&o.goarch, "goarch", "", "")
+	fs.StringVar(&o.includeCond, "include-condition", "", "")
+	fs.StringVar(&o.excludeCond, "exclude-condition", "", "")
+}
+
+// configure 套用 flag 到 Generator，flag 值不合法時回傳錯誤
+func (o *sourceOptions) configure(gen *swaggo.Generator) error {
+	if o.exclude != "" {
+		gen.WithExclude(splitList(o.exclude)...)
+	}
+
+	for _, fn := range splitList(o.roleFuncs) {
+		gen.WithAuthorizationRules(swaggo.AuthorizationRule{Function: fn, Kind: swaggo.AuthorizationRole})
+	}
+	for _, fn := range splitList(o.scopeFuncs) {
+		gen.WithAuthorizationRules(swaggo.AuthorizationRule{Function: fn, Kind: swaggo.AuthorizationScope})
+	}
+
+	switch o.thirdParty {
+	case swaggo.ThirdPartyDocument, swaggo.ThirdPartyInternal, swaggo.ThirdPartyExclude:
+		gen.WithThirdPartyRoutes(o.thirdParty)
+	default:
+		return fmt.Errorf("invalid --third-party mode: %s", o.thirdParty)
+	}
+
+	gen.WithBuildTags(splitList(o.buildTags)...).WithTarget(o.goos, o.goarch)
+	gen.WithIncludeConditions(splitList(o.includeCond)...).WithExcludeConditions(splitList(o.excludeCond)...)
+
+	gen.SetParseVendor(o.parseVendor)
+	gen.SetParseDependency(o.parseDeps)
+	gen.SetPruneUnreachable(o.prune)
+	return nil
+}
+
+// parse 以全掃或入口模式解析原始碼，回傳專案根目錄的絕對路徑
+func (o *sourceOptions) parse(gen *swaggo.Generator, log func(string, ...any)) (string, error) {
+	absDir, _ := filepath.Abs(o.dir)
+
+	if o.entry == "" {
+		log("Parsing: %s\n", absDir)
+		return absDir, gen.ParseSource(o.dir)
+	}
+
+	entryPath := filepath.Join(absDir, o.entry)
+	if _, err := os.Stat(entryPath); os.IsNotExist(err) {
+		return absDir, fmt.Errorf("entry file not found: %s", entryPath)
+	}
+	log("Entry: %s\n", o.entry)
+	log("Root:  %s\n", absDir)
+	return absDir, gen.ParseFromEntry(entryPath, absDir)
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/miyago9267/swaggo/pkg/swaggo"
+)
+
+// routeRow 路由表的一列
+type routeRow struct {
+	Method      string   `json:"method"`
+	Path        string   `json:"path"`
+	Handler     string   `json:"handler"`
+	Middlewares []string `json:"middlewares"`
+	Group       string   `json:"group,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Source      string   `json:"source,omitempty"`
+}
+
+// runRoutes 執行 swaggo routes：只解析原始碼，列出找到的路由
+func runRoutes(args []string) {
+	var (
+		src      sourceOptions
+		format   string
+		sortBy   string
+		prefix   string
+		packages string
+	)
+
+	fs := flag.NewFlagSet("routes", flag.ExitOnError)
+	src.register(fs)
+	fs.StringVar(&format, "format", "table", "")
+	fs.StringVar(&sortBy, "sort", "path", "")
+	fs.StringVar(&prefix, "prefix", "", "")
+	fs.StringVar(&packages, "package", "", "")
+
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, `swaggo routes - List routes discovered in the source
+
+Usage:
+  swaggo routes [flags]
+
+Examples:
+  swaggo routes -d .
+  swaggo routes -d . --prefix /api/v1 --sort handler
+  swaggo routes -d . --package users,orders --format csv > routes.csv
+
+Flags:
+`+sourceFlagsUsage+`      --format <fmt>        Output format: table, json, csv (default "table")
+      --sort <key>          Sort by path, method, handler or source (default "path")
+      --prefix <path>       Only routes whose path starts with the prefix
+      --package <pkgs>      Only routes whose handler belongs to the packages (comma separated)
+`)
+	}
+	fs.Parse(args)
+
+	switch format {
+	case "table", "json", "csv":
+	default:
+		fmt.Fprintf(os.Stderr, "Invalid --format: %s\n", format)
+		os.Exit(1)
+	}
+	switch sortBy {
+	case "path", "method", "handler", "source":
+	default:
+		fmt.Fprintf(os.Stderr, "Invalid --sort key: %s\n", sortBy)
+		os.Exit(1)
+	}
+
+	gen := swaggo.New()
+	if err := src.configure(gen); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	absDir, err := src.parse(gen, func(string, ...any) {})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Parse error: %v\n", err)
+		os.Exit(1)
+	}
+
+	routes := filterRoutes(gen.Routes(), prefix, splitList(packages))
+	routes = sortRoutes(routes, sortBy)
+
+	switch format {
+	case "table":
+		err = writeRouteTable(os.Stdout, routes, absDir)
+	case "json":
+		err = writeRouteJSON(os.Stdout, routes, absDir)
+	case "csv":
+		err = writeRouteCSV(os.Stdout, routes, absDir)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Write routes error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// filterRoutes 以 path prefix 與 handler 所屬 package 過濾路由，條件為空時不過濾
+func filterRoutes(routes []*swaggo.RouteInfo, prefix string, packages []string) []*swaggo.RouteInfo {
+	var result []*swaggo.RouteInfo
+	for _, route := range routes {
+		if prefix != "" && !strings.HasPrefix(route.Path, prefix) {
+			continue
+		}
+		if len(packages) > 0 && !containsString(packages, handlerPackage(route)) {
+			continue
+		}
+		result = append(result, route)
+	}
+	return result
+}
+
+// handlerPackage 回傳 handler 所屬的 package 名稱；handler 未解析時取名稱的第一段
+func handlerPackage(route *swaggo.RouteInfo) string {
+	if route.Handler != nil && route.Handler.Package != "" {
+		return route.Handler.Package
+	}
+	pkg, _, _ := strings.Cut(route.HandlerName, ".")
+	return pkg
+}
+
+// sortRoutes 依 key 排序，回傳新的 slice
+func sortRoutes(routes []*swaggo.RouteInfo, key string) []*swaggo.RouteInfo {
+	sorted := append([]*swaggo.RouteInfo{}, routes...)
+	byPath := func(a, b *swaggo.RouteInfo) bool {
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return strings.ToUpper(a.Method) < strings.ToUpper(b.Method)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		switch key {
+		case "method":
+			if ma, mb := strings.ToUpper(a.Method), strings.ToUpper(b.Method); ma != mb {
+				return ma < mb
+			}
+		case "handler":
+			if a.HandlerName != b.HandlerName {
+				return a.HandlerName < b.HandlerName
+			}
+		case "source":
+			if a.Position.Filename != b.Position.Filename {
+				return a.Position.Filename < b.Position.Filename
+			}
+			if a.Position.Line != b.Position.Line {
+				return a.Position.Line < b.Position.Line
+			}
+		}
+		return byPath(a, b)
+	})
+	return sorted
+}
+
+func newRouteRow(route *swaggo.RouteInfo, root string) routeRow {
+	row := routeRow{
+		Method:      strings.ToUpper(route.Method),
+		Path:        route.Path,
+		Handler:     route.HandlerName,
+		Middlewares: []string{},
+		Group:       route.Group,
+		Tags:        route.Tags(),
+		Source:      sourcePosition(route, root),
+	}
+	for _, mw := range route.Middlewares {
+		row.Middlewares = append(row.Middlewares, mw.Name)
+	}
+	return row
+}
+
+// sourcePosition 以相對於專案根目錄的 file:line 表示路由的註冊位置
+func sourcePosition(route *swaggo.RouteInfo, root string) string {
+	if !route.Position.IsValid() {
+		return ""
+	}
+	file := route.Position.Filename
+	if abs, err := filepath.Abs(file); err == nil {
+		if rel, err := filepath.Rel(root, abs); err == nil && !strings.HasPrefix(rel, "..") {
+			file = rel
+		}
+	}
+	return fmt.Sprintf("%s:%d", filepath.ToSlash(file), route.Position.Line)
+}
+
+func writeRouteTable(w io.Writer, routes []*swaggo.RouteInfo, root string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "METHOD\tPATH\tHANDLER\tMIDDLEWARE\tGROUP\tTAGS\tSOURCE")
+	for _, route := range routes {
+		row := newRouteRow(route, root)
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", row.Method, row.Path, row.Handler,
+			orDash(strings.Join(row.Middlewares, " > ")), orDash(row.Group), orDash(strings.Join(row.Tags, ",")), orDash(row.Source))
+	}
+	return tw.Flush()
+}
+
+func writeRouteJSON(w io.Writer, routes []*swaggo.RouteInfo, root string) error {
+	rows := make([]routeRow, 0, len(routes))
+	for _, route := range routes {
+		rows = append(rows, newRouteRow(route, root))
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rows)
+}
+
+func writeRouteCSV(w io.Writer, routes []*swaggo.RouteInfo, root string) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"method", "path", "handler", "middlewares", "group", "tags", "source"})
+	for _, route := range routes {
+		row := newRouteRow(route, root)
+		cw.Write([]string{row.Method, row.Path, row.Handler, strings.Join(row.Middlewares, ";"),
+			row.Group, strings.Join(row.Tags, ";"), row.Source})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
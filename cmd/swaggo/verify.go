@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/miyago9267/swaggo/pkg/swaggo"
+)
+
+// mismatchRow verify-routes JSON 輸出的一筆差異
+type mismatchRow struct {
+	Kind           string `json:"kind"`
+	Method         string `json:"method"`
+	Path           string `json:"path"`
+	StaticHandler  string `json:"staticHandler,omitempty"`
+	RuntimeHandler string `json:"runtimeHandler,omitempty"`
+	Source         string `json:"source,omitempty"`
+}
+
+// runVerifyRoutes 執行 swaggo verify-routes：比對靜態分析與執行期註冊的路由，有差異時 exit 1
+func runVerifyRoutes(args []string) {
+	var (
+		src    sourceOptions
+		input  string
+		format string
+	)
+
+	fs := flag.NewFlagSet("verify-routes", flag.ExitOnError)
+	src.register(fs)
+	fs.StringVar(&input, "input", "", "")
+	fs.StringVar(&input, "i", "", "")
+	fs.StringVar(&format, "format", "human", "")
+
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, `swaggo verify-routes - Compare static routes with routes registered at runtime
+
+Usage:
+  swaggo verify-routes [flags] <file>
+
+The file is either gin's debug output ([GIN-debug] GET /path --> handler) or a
+JSON array of {"method", "path", "handler"} as returned by gin's Engine.Routes().
+Use "-" to read from stdin. Exits with status 1 when any mismatch is found.
+
+Examples:
+  swaggo verify-routes -d . gin-debug.log
+  go run ./cmd/api 2>&1 | swaggo verify-routes -d . -
+  swaggo verify-routes -d . -e cmd/api/main.go --format json routes.json
+
+Flags:
+`+sourceFlagsUsage+`  -i, --input <file>        Runtime routes file (alternative to the positional argument)
+      --format <fmt>        Output format: human, json (default "human")
+`)
+	}
+	fs.Parse(args)
+
+	if input == "" && fs.NArg() > 0 {
+		input = fs.Arg(0)
+	}
+	if input == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+	switch format {
+	case "human", "json":
+	default:
+		fmt.Fprintf(os.Stderr, "Invalid --format: %s\n", format)
+		os.Exit(2)
+	}
+
+	runtime, err := readRuntimeRoutes(input)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Read runtime routes error: %v\n", err)
+		os.Exit(2)
+	}
+
+	gen := swaggo.New()
+	if err := src.configure(gen); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+	absDir, err := src.parse(gen, func(string, ...any) {})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Parse error: %v\n", err)
+		os.Exit(2)
+	}
+
+	mismatches := swaggo.CompareRoutes(gen.Routes(), runtime)
+
+	switch format {
+	case "human":
+		for _, m := range mismatches {
+			fmt.Println(formatMismatch(m, absDir))
+		}
+		fmt.Printf("%d static routes, %d runtime routes, %d mismatches\n", len(gen.Routes()), len(runtime), len(mismatches))
+	case "json":
+		err = writeMismatchJSON(os.Stdout, mismatches, absDir)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Write mismatches error: %v\n", err)
+		os.Exit(2)
+	}
+
+	if len(mismatches) > 0 {
+		os.Exit(1)
+	}
+}
+
+// readRuntimeRoutes 從檔案或 stdin（"-"）讀取執行期路由
+func readRuntimeRoutes(input string) ([]swaggo.RuntimeRoute, error) {
+	if input == "-" {
+		return swaggo.ReadRuntimeRoutes(os.Stdin)
+	}
+	f, err := os.Open(input)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return swaggo.ReadRuntimeRoutes(f)
+}
+
+// formatMismatch 與 RouteMismatch.String 相同，但位置改為相對於專案根目錄
+func formatMismatch(m swaggo.RouteMismatch, root string) string {
+	pos := m.Position
+	m.Position.Filename, m.Position.Line = "", 0
+	s := m.String()
+	if source := sourcePosition(&swaggo.RouteInfo{Position: pos}, root); source != "" {
+		s = source + ": " + s
+	}
+	return s
+}
+
+func writeMismatchJSON(w io.Writer, mismatches []swaggo.RouteMismatch, root string) error {
+	rows := make([]mismatchRow, 0, len(mismatches))
+	for _, m := range mismatches {
+		rows = append(rows, mismatchRow{
+			Kind:           m.Kind,
+			Method:         m.Method,
+			Path:           m.Path,
+			StaticHandler:  m.StaticHandler,
+			RuntimeHandler: m.RuntimeHandler,
+			Source:         sourcePosition(&swaggo.RouteInfo{Position: m.Position}, root),
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rows)
+}
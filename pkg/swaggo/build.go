@@ -0,0 +1,24 @@
+package swaggo
+
+import (
+	"go/build"
+	"os"
+	"strings"
+)
+
+// defaultBuildContext 以目前環境的 GOOS/GOARCH 評估 build constraint，不啟用額外的 build tag
+func defaultBuildContext() build.Context {
+	ctx := build.Default
+	ctx.BuildTags = nil
+	return ctx
+}
+
+// matchBuildFile 判斷檔案是否會被編譯進目標 binary：排除測試檔，
+// 並依 //go:build、GOOS/GOARCH 檔名後綴（例如 _windows.go）與設定的 build tag 評估
+func (p *Parser) matchBuildFile(dir string, fi os.FileInfo) bool {
+	if strings.HasSuffix(fi.Name(), "_test.go") {
+		return false
+	}
+	match, err := p.buildContext.MatchFile(dir, fi.Name())
+	return err == nil && match
+}
@@ -0,0 +1,82 @@
+package swaggo
+
+import "testing"
+
+func TestBuildConstraints(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"main.go": `package main
+
+import "github.com/gin-gonic/gin"
+
+func main() {
+	r := gin.Default()
+	r.GET("/health", Health)
+	registerEdition(r)
+}
+
+func Health(c *gin.Context) { c.JSON(200, nil) }
+`,
+		"community.go": `//go:build !enterprise
+
+package main
+
+import "github.com/gin-gonic/gin"
+
+func registerEdition(r *gin.Engine) {}
+`,
+		"enterprise.go": `//go:build enterprise
+
+package main
+
+import "github.com/gin-gonic/gin"
+
+func registerEdition(r *gin.Engine) {
+	r.GET("/audit", Audit)
+}
+
+func Audit(c *gin.Context) { c.JSON(200, nil) }
+`,
+		"gen.go": `//go:build ignore
+
+package main
+
+import "github.com/gin-gonic/gin"
+
+func main() {
+	r := gin.Default()
+	r.GET("/phantom", Health)
+}
+`,
+		"service_windows.go": `package main
+
+import "github.com/gin-gonic/gin"
+
+func init() {
+	gin.Default().GET("/windows", Health)
+}
+`,
+	})
+
+	analyze := func(gen *Generator) *Parser {
+		t.Helper()
+		if err := gen.ParseSource(root); err != nil {
+			t.Fatal(err)
+		}
+		return gen.parser
+	}
+
+	community := analyze(New().WithTarget("linux", "amd64"))
+	for path, want := range map[string]bool{"/health": true, "/audit": false, "/phantom": false, "/windows": false} {
+		if got := findRoute(community, "GET", path) != nil; got != want {
+			t.Errorf("community build: GET %s present = %v, want %v", path, got, want)
+		}
+	}
+
+	enterprise := analyze(New().WithTarget("windows", "amd64").WithBuildTags("enterprise"))
+	for path, want := range map[string]bool{"/health": true, "/audit": true, "/phantom": false, "/windows": true} {
+		if got := findRoute(enterprise, "GET", path) != nil; got != want {
+			t.Errorf("enterprise windows build: GET %s present = %v, want %v", path, got, want)
+		}
+	}
+}
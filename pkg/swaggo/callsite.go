@@ -2,6 +2,7 @@ package swaggo
 
 import (
 	"go/ast"
+	"go/token"
 	"strings"
 )
 
@@ -20,6 +21,10 @@ type RouteRegistrar struct {
 type CallSite struct {
 	Registrar   *RouteRegistrar
 	GroupPrefix string
+	Middlewares []*MiddlewareInfo
+
+	groupArg   ast.Expr
+	conditions []string // 呼叫點外層的 if / switch 條件
 }
 
 // collectRouteRegistrars 收集所有接受 *gin.RouterGroup 或 *gin.Engine 的函數
@@ -53,7 +58,7 @@ func (p *Parser) collectRegistrarsFromFile(file *ast.File, registrars map[string
 
 func (p *Parser) tryBuildRegistrar(fn *ast.FuncDecl, pkgName string, file *ast.File) *RouteRegistrar {
 	for _, param := range fn.Type.Params.List {
-		paramType := p.getGinParamType(param.Type)
+		paramType := p.routerParamType(param.Type)
 		if paramType == "" {
 			continue
 		}
@@ -75,6 +80,19 @@ func (p *Parser) tryBuildRegistrar(fn *ast.FuncDecl, pkgName string, file *ast.F
 			FuncDecl:  fn,
 		}
 	}
+
+	// 自行建立 chi router 並回傳的函數，通常以 r.Mount(prefix, fn()) 掛載
+	if routerVar := chiRouterVar(fn); routerVar != "" {
+		return &RouteRegistrar{
+			Package:   pkgName,
+			Name:      fn.Name.Name,
+			FullName:  p.buildFuncFullName(fn, pkgName),
+			ParamName: routerVar,
+			ParamType: "NewRouter",
+			File:      file,
+			FuncDecl:  fn,
+		}
+	}
 	return nil
 }
 
@@ -130,18 +148,35 @@ func (p *Parser) findCallSites(registrars map[string]*RouteRegistrar) []CallSite
 
 func (p *Parser) findCallSitesInFile(file *ast.File, registrars map[string]*RouteRegistrar) []CallSite {
 	pkgName := file.Name.Name
+	framework := p.fileFramework(file)
 	groupPrefixes := p.collectGroupPrefixes(file)
+	groupMiddlewares := p.collectGroupMiddlewares(file)
 
 	var callSites []CallSite
 
-	ast.Inspect(file, func(n ast.Node) bool {
+	p.inspectRouterScopes(file, pkgName, framework, groupPrefixes, groupMiddlewares, func(n ast.Node, groupPrefixes map[string]string, groupMiddlewares map[string][]*MiddlewareInfo) bool {
 		call, ok := n.(*ast.CallExpr)
 		if !ok {
 			return true
 		}
 
+		// registrar 內的 Mount 由 extractRoutesWithPrefixDepth 帶著外層 prefix 處理
+		if site := p.tryBuildMountCallSite(call, pkgName, groupPrefixes, groupMiddlewares, registrars); site != nil {
+			if !p.inRegistrarBody(file, call.Pos()) {
+				callSites = append(callSites, *site)
+			}
+			return false
+		}
+
+		// m.Register(api)，m 為 interface 或 range 的元素時展開為各具體型別的 registrar
+		if sites := p.tryBuildInterfaceCallSites(call, file, pkgName, groupPrefixes, groupMiddlewares, registrars); len(sites) > 0 {
+			callSites = append(callSites, sites...)
+			return true
+		}
+
 		site := p.tryBuildCallSite(call, pkgName, groupPrefixes, registrars)
 		if site != nil {
+			site.Middlewares = p.resolveGroupMiddlewares(site.groupArg, groupMiddlewares)
 			callSites = append(callSites, *site)
 		}
 
@@ -152,7 +187,7 @@ func (p *Parser) findCallSitesInFile(file *ast.File, registrars map[string]*Rout
 }
 
 func (p *Parser) collectGroupPrefixes(file *ast.File) map[string]string {
-	prefixes := make(map[string]string)
+	prefixes := p.collectMountPrefixes(file)
 
 	ast.Inspect(file, func(n ast.Node) bool {
 		assign, ok := n.(*ast.AssignStmt)
@@ -180,8 +215,9 @@ func (p *Parser) collectGroupPrefixes(file *ast.File) map[string]string {
 }
 
 type groupCallInfo struct {
-	parentVar string
-	prefix    string
+	parentVar   string
+	prefix      string
+	middlewares []ast.Expr
 }
 
 func (p *Parser) extractGroupCall(expr ast.Expr) *groupCallInfo {
@@ -189,23 +225,35 @@ func (p *Parser) extractGroupCall(expr ast.Expr) *groupCallInfo {
 	if !ok {
 		return nil
 	}
+	if _, ok := call.Fun.(*ast.SelectorExpr); !ok {
+		return nil
+	}
 
+	for _, fw := range p.frameworks {
+		group := fw.ParseGroup(call)
+		if group == nil || group.Prefix == "" {
+			continue
+		}
+		parentVar := ""
+		if ident, ok := group.Parent.(*ast.Ident); ok {
+			parentVar = ident.Name
+		}
+		return &groupCallInfo{parentVar: parentVar, prefix: group.Prefix, middlewares: group.Middlewares}
+	}
+	return nil
+}
+
+// parseGroupCall 解析 gin / echo / fiber 共用的 r.Group(prefix, ...middlewares)
+func (p *Parser) parseGroupCall(call *ast.CallExpr) *GroupCall {
 	sel, ok := call.Fun.(*ast.SelectorExpr)
 	if !ok || sel.Sel.Name != "Group" || len(call.Args) == 0 {
 		return nil
 	}
-
 	prefix := p.extractStringArg(call.Args[0])
 	if prefix == "" {
 		return nil
 	}
-
-	parentVar := ""
-	if ident, ok := sel.X.(*ast.Ident); ok {
-		parentVar = ident.Name
-	}
-
-	return &groupCallInfo{parentVar: parentVar, prefix: prefix}
+	return &GroupCall{Parent: sel.X, Prefix: prefix, Middlewares: call.Args[1:]}
 }
 
 func (p *Parser) getAssignTarget(lhs []ast.Expr, index int) string {
@@ -234,6 +282,8 @@ func (p *Parser) tryBuildCallSite(call *ast.CallExpr, pkgName string, groupPrefi
 	return &CallSite{
 		Registrar:   reg,
 		GroupPrefix: prefix,
+		groupArg:    groupArg,
+		conditions:  p.guardConditions(call.Pos()),
 	}
 }
 
@@ -243,12 +293,15 @@ func (p *Parser) extractCallInfo(call *ast.CallExpr, pkgName string) (funcName s
 		funcName = pkgName + "." + fn.Name
 	case *ast.SelectorExpr:
 		if ident, ok := fn.X.(*ast.Ident); ok {
-			// 單層: ident.Method
+			// 單層: ident.Method，已知型別的變數以型別名稱精確比對
 			funcName = ident.Name + "." + fn.Sel.Name
+			if typeName, ok := p.instanceType(ident); ok {
+				funcName = typeName + "." + fn.Sel.Name
+			}
 		} else if sel, ok := fn.X.(*ast.SelectorExpr); ok {
 			// 多層: a.field.Method → 嘗試透過 struct field 解析類型
 			if rootIdent, ok := sel.X.(*ast.Ident); ok {
-				if fieldType := p.resolveFieldType(rootIdent.Name, sel.Sel.Name); fieldType != "" {
+				if fieldType := p.resolveFieldType(rootIdent, sel.Sel.Name); fieldType != "" {
 					funcName = fieldType + "." + fn.Sel.Name
 				} else {
 					funcName = fn.Sel.Name
@@ -271,10 +324,12 @@ func (p *Parser) extractCallInfo(call *ast.CallExpr, pkgName string) (funcName s
 func (p *Parser) matchRegistrar(funcName string, registrars map[string]*RouteRegistrar) *RouteRegistrar {
 	simpleName := p.getSimpleName(funcName)
 
+	// 精確匹配優先，避免同名 method 在 map 迭代順序下被隨機選中
+	if reg, ok := registrars[funcName]; ok {
+		return reg
+	}
+
 	for fullName, reg := range registrars {
-		if fullName == funcName {
-			return reg
-		}
 		if strings.HasSuffix(fullName, "."+simpleName) {
 			return reg
 		}
@@ -298,10 +353,17 @@ func (p *Parser) resolveGroupPrefix(groupArg ast.Expr, prefixes map[string]strin
 
 // extractRoutesWithPrefix 在指定的 prefix 下解析路由註冊函數
 func (p *Parser) extractRoutesWithPrefix(registrar *RouteRegistrar, basePrefix string) {
-	p.extractRoutesWithPrefixDepth(registrar, basePrefix, 0)
+	p.extractRoutesWithPrefixDepth(registrar, basePrefix, nil, 0)
 }
 
-func (p *Parser) extractRoutesWithPrefixDepth(registrar *RouteRegistrar, basePrefix string, depth int) {
+// extractRoutesFromCallSite 以呼叫點的 group prefix 與 middleware 解析路由註冊函數
+func (p *Parser) extractRoutesFromCallSite(cs CallSite) {
+	p.withCallConditions(cs.conditions, func() {
+		p.extractRoutesWithPrefixDepth(cs.Registrar, cs.GroupPrefix, cs.Middlewares, 0)
+	})
+}
+
+func (p *Parser) extractRoutesWithPrefixDepth(registrar *RouteRegistrar, basePrefix string, baseMiddlewares []*MiddlewareInfo, depth int) {
 	if registrar.FuncDecl == nil || registrar.FuncDecl.Body == nil {
 		return
 	}
@@ -312,22 +374,41 @@ func (p *Parser) extractRoutesWithPrefixDepth(registrar *RouteRegistrar, basePre
 	}
 
 	pkgName := registrar.Package
+	framework := p.fileFramework(registrar.File)
 	groupPrefixes := make(map[string]string)
+	groupMiddlewares := make(map[string][]*MiddlewareInfo)
 
 	if registrar.ParamName != "" {
 		groupPrefixes[registrar.ParamName] = basePrefix
+		groupMiddlewares[registrar.ParamName] = baseMiddlewares
 	}
 
-	p.registerReceiverInstance(registrar.FuncDecl, pkgName)
-
-	ast.Inspect(registrar.FuncDecl.Body, func(n ast.Node) bool {
+	p.inspectRouterScopes(registrar.FuncDecl.Body, pkgName, framework, groupPrefixes, groupMiddlewares, func(n ast.Node, groupPrefixes map[string]string, groupMiddlewares map[string][]*MiddlewareInfo) bool {
 		switch node := n.(type) {
 		case *ast.AssignStmt:
 			p.updateGroupPrefixes(node, groupPrefixes)
+			p.updateGroupMiddlewares(node, pkgName, groupMiddlewares)
 		case *ast.CallExpr:
-			p.tryAddRouteFromCall(node, pkgName, groupPrefixes)
+			// r.Mount("/admin", adminRouter()) 以掛載的 prefix 解析子 router
+			if site := p.tryBuildMountCallSite(node, pkgName, groupPrefixes, groupMiddlewares, p.routeRegistrars); site != nil {
+				p.withCallConditions(site.conditions, func() {
+					p.extractRoutesWithPrefixDepth(site.Registrar, site.GroupPrefix, site.Middlewares, depth+1)
+				})
+				return false
+			}
+			p.collectUseCall(node, pkgName, groupMiddlewares)
+			p.tryAddThirdPartyRoutes(node, groupPrefixes, groupMiddlewares)
+			p.tryAddRouteFromCall(node, pkgName, framework, groupPrefixes, groupMiddlewares)
 			// 嘗試追蹤 registrar 內部對其他 registrar 的呼叫
-			p.tryFollowNestedRegistrar(node, pkgName, groupPrefixes, depth)
+			if sites := p.tryBuildInterfaceCallSites(node, registrar.File, pkgName, groupPrefixes, groupMiddlewares, p.routeRegistrars); len(sites) > 0 {
+				for _, site := range sites {
+					p.withCallConditions(site.conditions, func() {
+						p.extractRoutesWithPrefixDepth(site.Registrar, site.GroupPrefix, site.Middlewares, depth+1)
+					})
+				}
+				return true
+			}
+			p.tryFollowNestedRegistrar(node, pkgName, groupPrefixes, groupMiddlewares, depth)
 		}
 		return true
 	})
@@ -335,7 +416,7 @@ func (p *Parser) extractRoutesWithPrefixDepth(registrar *RouteRegistrar, basePre
 
 // tryFollowNestedRegistrar 偵測 registrar body 內對其他 registrar 的呼叫
 // 例如：m.handler.RegisterRoutes(r) 或 subRegistrar(r)
-func (p *Parser) tryFollowNestedRegistrar(call *ast.CallExpr, pkgName string, groupPrefixes map[string]string, depth int) {
+func (p *Parser) tryFollowNestedRegistrar(call *ast.CallExpr, pkgName string, groupPrefixes map[string]string, groupMiddlewares map[string][]*MiddlewareInfo, depth int) {
 	funcName, groupArg := p.extractCallInfo(call, pkgName)
 	if funcName == "" {
 		return
@@ -347,16 +428,22 @@ func (p *Parser) tryFollowNestedRegistrar(call *ast.CallExpr, pkgName string, gr
 	}
 
 	prefix := p.resolveGroupPrefix(groupArg, groupPrefixes)
-	p.extractRoutesWithPrefixDepth(reg, prefix, depth+1)
+	middlewares := p.resolveGroupMiddlewares(groupArg, groupMiddlewares)
+	p.withCallConditions(p.guardConditions(call.Pos()), func() {
+		p.extractRoutesWithPrefixDepth(reg, prefix, middlewares, depth+1)
+	})
 }
 
 // findIndirectCallSites 找出透過函數引用傳遞的間接 registrar 呼叫
-// 例如: SetupRoutes(bookingsModule.RegisterRoutes, inventoryModule.RegisterRoutes)
+// 例如: SetupRoutes(api, bookingsModule.RegisterRoutes, inventoryModule.RegisterRoutes)
+// prefix 由接收函數內對該參數的呼叫決定，例如 SetupRoutes 內的 reg(v1)
 func (p *Parser) findIndirectCallSites(registrars map[string]*RouteRegistrar) []CallSite {
 	var callSites []CallSite
 
 	for _, file := range p.files {
 		pkgName := file.Name.Name
+		groupPrefixes := p.collectGroupPrefixes(file)
+		groupMiddlewares := p.collectGroupMiddlewares(file)
 
 		ast.Inspect(file, func(n ast.Node) bool {
 			call, ok := n.(*ast.CallExpr)
@@ -364,13 +451,21 @@ func (p *Parser) findIndirectCallSites(registrars map[string]*RouteRegistrar) []
 				return true
 			}
 
-			for _, arg := range call.Args {
-				reg := p.matchArgToRegistrar(arg, pkgName, registrars)
-				if reg != nil {
-					callSites = append(callSites, CallSite{
-						Registrar:   reg,
-						GroupPrefix: "",
-					})
+			for i, arg := range call.Args {
+				reg := p.matchArgToRegistrar(arg, file, pkgName, registrars)
+				if reg == nil {
+					continue
+				}
+				conds := p.guardConditions(call.Pos())
+				invocations := p.registrarInvocations(call, i, pkgName, groupPrefixes, groupMiddlewares)
+				if len(invocations) == 0 {
+					callSites = append(callSites, CallSite{Registrar: reg, conditions: conds})
+					continue
+				}
+				for _, site := range invocations {
+					site.Registrar = reg
+					site.conditions = append(append([]string{}, conds...), site.conditions...)
+					callSites = append(callSites, site)
 				}
 			}
 
@@ -381,67 +476,177 @@ func (p *Parser) findIndirectCallSites(registrars map[string]*RouteRegistrar) []
 	return callSites
 }
 
+// registrarInvocations 追蹤 call 的第 argIndex 個引數在被呼叫函數內的呼叫點，
+// 回傳每次呼叫的 group prefix 與 middleware（Registrar 由呼叫端填入）
+// 支援直接呼叫 reg(r) 與 variadic 參數的 for _, reg := range regs { reg(r) }
+func (p *Parser) registrarInvocations(call *ast.CallExpr, argIndex int, pkgName string, groupPrefixes map[string]string, groupMiddlewares map[string][]*MiddlewareInfo) []CallSite {
+	callee, calleePkg := p.calleeDecl(call, pkgName)
+	if callee == nil || callee.Body == nil || callee.Type.Params == nil {
+		return nil
+	}
+
+	var params []string
+	variadic := false
+	for _, field := range callee.Type.Params.List {
+		_, variadic = field.Type.(*ast.Ellipsis)
+		if len(field.Names) == 0 {
+			params = append(params, "")
+		}
+		for _, name := range field.Names {
+			params = append(params, name.Name)
+		}
+	}
+	if len(params) == 0 {
+		return nil
+	}
+
+	var paramName string
+	if variadic && argIndex >= len(params)-1 {
+		paramName = params[len(params)-1]
+	} else if argIndex < len(params) {
+		paramName = params[argIndex]
+	}
+	if paramName == "" || paramName == "_" {
+		return nil
+	}
+
+	// 被呼叫函數的 router 參數繼承呼叫端引數的 prefix
+	prefixes := make(map[string]string)
+	middlewares := make(map[string][]*MiddlewareInfo)
+	for i, name := range params {
+		if name == "" || i >= len(call.Args) {
+			continue
+		}
+		arg := call.Args[i]
+		if info := p.extractGroupCall(arg); info != nil {
+			prefixes[name] = groupPrefixes[info.parentVar] + info.prefix
+			middlewares[name] = appendMiddlewares(groupMiddlewares[info.parentVar], p.resolveMiddlewares(info.middlewares, pkgName)...)
+			continue
+		}
+		prefixes[name] = p.resolveGroupPrefix(arg, groupPrefixes)
+		middlewares[name] = p.resolveGroupMiddlewares(arg, groupMiddlewares)
+	}
+
+	funcVars := map[string]bool{paramName: true}
+	var sites []CallSite
+	ast.Inspect(callee.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			p.updateGroupPrefixes(node, prefixes)
+			p.updateGroupMiddlewares(node, calleePkg, middlewares)
+		case *ast.RangeStmt:
+			if x, ok := node.X.(*ast.Ident); ok && funcVars[x.Name] {
+				if value, ok := node.Value.(*ast.Ident); ok {
+					funcVars[value.Name] = true
+				}
+			}
+		case *ast.CallExpr:
+			fn, ok := node.Fun.(*ast.Ident)
+			if !ok || !funcVars[fn.Name] || len(node.Args) == 0 {
+				return true
+			}
+			sites = append(sites, CallSite{
+				GroupPrefix: p.resolveGroupPrefix(node.Args[0], prefixes),
+				Middlewares: p.resolveGroupMiddlewares(node.Args[0], middlewares),
+				groupArg:    node.Args[0],
+				conditions:  p.guardConditions(node.Pos()),
+			})
+		}
+		return true
+	})
+	return sites
+}
+
+// calleeDecl 找出被呼叫函數的宣告與其所在 package：Setup(...)、routes.Setup(...) 或 app.Setup(...)
+func (p *Parser) calleeDecl(call *ast.CallExpr, pkgName string) (*ast.FuncDecl, string) {
+	var fullName string
+	switch fn := call.Fun.(type) {
+	case *ast.Ident:
+		fullName = pkgName + "." + fn.Name
+	case *ast.SelectorExpr:
+		ident, ok := fn.X.(*ast.Ident)
+		if !ok {
+			return nil, ""
+		}
+		fullName = ident.Name + "." + fn.Sel.Name
+		if typeName, ok := p.instanceType(ident); ok {
+			fullName = typeName + "." + fn.Sel.Name
+		}
+	default:
+		return nil, ""
+	}
+
+	decl, ok := p.funcDecls[fullName]
+	if !ok {
+		return nil, ""
+	}
+	return decl, strings.SplitN(fullName, ".", 2)[0]
+}
+
 // matchArgToRegistrar 檢查 call argument 是否為已知 registrar 的函數引用
-func (p *Parser) matchArgToRegistrar(arg ast.Expr, pkgName string, registrars map[string]*RouteRegistrar) *RouteRegistrar {
+// 以接收者型別或 package 精確比對；只有名稱唯一時才退回以名稱比對，避免同名的 RegisterRoutes 互相混淆
+func (p *Parser) matchArgToRegistrar(arg ast.Expr, file *ast.File, pkgName string, registrars map[string]*RouteRegistrar) *RouteRegistrar {
 	switch expr := arg.(type) {
 	case *ast.SelectorExpr:
-		// mod.RegisterRoutes（method value）
+		// mod.RegisterRoutes（method value）或 users.RegisterRoutes（package function）
 		methodName := expr.Sel.Name
+		ident, ok := expr.X.(*ast.Ident)
+		if !ok {
+			return p.uniqueRegistrar(methodName, registrars)
+		}
 
-		// 嘗試用 controller instance 做精確匹配
-		if ident, ok := expr.X.(*ast.Ident); ok {
-			if ctrlType, ok := p.controllerInstances[ident.Name]; ok {
-				fullName := ctrlType + "." + methodName
-				if reg, ok := registrars[fullName]; ok {
-					return reg
-				}
+		if reg, ok := registrars[ident.Name+"."+methodName]; ok {
+			return reg
+		}
+
+		// 依變數在所在函數內的賦值推斷接收者型別
+		concrete, declared := p.receiverTypes(ident, arg.Pos(), file, pkgName)
+		if declared != "" {
+			concrete = append(concrete, declared)
+		}
+		for _, typeName := range concrete {
+			if reg, ok := registrars[typeName+"."+methodName]; ok {
+				return reg
 			}
 		}
 
-		// Fallback：用方法名匹配
-		for _, reg := range registrars {
-			if reg.Name == methodName {
+		if ctrlType, ok := p.instanceType(ident); ok {
+			if reg, ok := registrars[ctrlType+"."+methodName]; ok {
 				return reg
 			}
 		}
+		return p.uniqueRegistrar(methodName, registrars)
 
 	case *ast.Ident:
 		// RegisterRoutes（同 package 的 bare function reference）
-		funcName := pkgName + "." + expr.Name
-		if reg, ok := registrars[funcName]; ok {
+		if reg, ok := registrars[pkgName+"."+expr.Name]; ok {
 			return reg
 		}
-		for _, reg := range registrars {
-			if reg.Name == expr.Name {
-				return reg
-			}
-		}
+		return p.uniqueRegistrar(expr.Name, registrars)
 	}
 
 	return nil
 }
 
-func (p *Parser) registerReceiverInstance(fn *ast.FuncDecl, pkgName string) {
-	if fn.Recv == nil || len(fn.Recv.List) == 0 {
-		return
-	}
-
-	recv := fn.Recv.List[0]
-	if len(recv.Names) == 0 {
-		return
-	}
-
-	recvVarName := recv.Names[0].Name
-	recvType := p.extractReceiverType(recv.Type)
-	if recvType != "" {
-		p.controllerInstances[recvVarName] = pkgName + "." + recvType
+// uniqueRegistrar 以名稱比對 registrar，有多個同名時無法判斷而回傳 nil
+func (p *Parser) uniqueRegistrar(name string, registrars map[string]*RouteRegistrar) *RouteRegistrar {
+	var found *RouteRegistrar
+	for _, reg := range registrars {
+		if reg.Name != name {
+			continue
+		}
+		if found != nil {
+			return nil
+		}
+		found = reg
 	}
+	return found
 }
 
-// resolveFieldType 透過 controllerInstances 和 Types 解析 varName.fieldName 的類型
+// resolveFieldType 透過 controller instance 和 Types 解析 ident.fieldName 的類型
 // 例如：m 的類型是 main.Module，Module 有 field handler *Handler → 回傳 "main.Handler"
-func (p *Parser) resolveFieldType(varName, fieldName string) string {
-	typeName, ok := p.controllerInstances[varName]
+func (p *Parser) resolveFieldType(ident *ast.Ident, fieldName string) string {
+	typeName, ok := p.instanceType(ident)
 	if !ok {
 		return ""
 	}
@@ -487,62 +692,58 @@ func (p *Parser) updateGroupPrefixes(assign *ast.AssignStmt, prefixes map[string
 	}
 }
 
-func (p *Parser) tryAddRouteFromCall(call *ast.CallExpr, pkgName string, groupPrefixes map[string]string) {
+func (p *Parser) tryAddRouteFromCall(call *ast.CallExpr, pkgName string, framework Framework, groupPrefixes map[string]string, groupMiddlewares map[string][]*MiddlewareInfo) {
 	sel, ok := call.Fun.(*ast.SelectorExpr)
 	if !ok {
 		return
 	}
 
-	method := sel.Sel.Name
-	if !isHTTPMethod(method) {
-		return
-	}
+	groupPrefix := p.getReceiverPrefix(sel.X, groupPrefixes)
 
-	// Handle() 的簽名是 Handle(method, path, ...handlers)，需要至少 3 個引數
-	// 其他 HTTP method 的簽名是 GET(path, ...handlers)，需要至少 2 個引數
-	if method == "Handle" {
-		if len(call.Args) < 3 {
-			return
+	for _, rc := range p.parseRouteCall(call, framework) {
+		handlerName := p.resolveHandlerName(rc.Handler, pkgName)
+		if handlerName == "" {
+			p.addDiagnostic(DiagUnresolvedHandler, call.Pos(), "%s %s%s handler is not a named function or method, route skipped", rc.Method, groupPrefix, rc.Path)
+			continue
 		}
-	} else {
-		if len(call.Args) < 2 {
-			return
+		if shouldSkipHandler(handlerName) {
+			continue
 		}
-	}
-
-	var httpMethod, path string
-	if method == "Handle" {
-		httpMethod = p.extractStringArg(call.Args[0])
-		path = p.extractStringArg(call.Args[1])
-	} else {
-		httpMethod = method
-		path = p.extractStringArg(call.Args[0])
-	}
-
-	groupPrefix := p.getReceiverPrefix(sel.X, groupPrefixes)
-	fullPath := groupPrefix + path
 
-	handlerArg := call.Args[len(call.Args)-1]
-	handlerName := p.resolveHandlerName(handlerArg, pkgName)
+		route := p.newRouteInfo(rc, groupPrefix, handlerName, p.routeMiddlewares(rc, pkgName, p.resolveGroupMiddlewares(sel.X, groupMiddlewares)))
+		if route.Path == "" {
+			p.addDiagnostic(DiagEmptyRoutePath, call.Pos(), "%s route path %s, route skipped", route.Method, routePathProblem(call))
+			continue
+		}
+		if route.Method == "" {
+			p.addDiagnostic(DiagUnresolvedMethod, call.Pos(), "route %s method is not a string literal or Method constant, route skipped", route.Path)
+			continue
+		}
+		if p.routeExists(route.Method, route.Path) {
+			continue
+		}
 
-	if handlerName == "" || shouldSkipHandler(handlerName) {
-		return
+		route.Registered = p.registeredFunc(rc, pkgName)
+		route.pos = call.Pos()
+		p.Routes = append(p.Routes, route)
 	}
+}
 
-	if p.routeExists(httpMethod, fullPath) {
-		return
+// inRegistrarBody 檢查位置是否在 registrar 函數內（這些路由會由 extractRoutesWithPrefix 處理）
+func (p *Parser) inRegistrarBody(file *ast.File, pos token.Pos) bool {
+	for _, reg := range p.routeRegistrars {
+		if reg.File != file || reg.FuncDecl == nil || reg.FuncDecl.Body == nil {
+			continue
+		}
+		if pos >= reg.FuncDecl.Body.Pos() && pos <= reg.FuncDecl.Body.End() {
+			return true
+		}
 	}
-
-	p.Routes = append(p.Routes, &RouteInfo{
-		Method:      httpMethod,
-		Path:        fullPath,
-		HandlerName: handlerName,
-		Group:       groupPrefix,
-	})
+	return false
 }
 
 func (p *Parser) getReceiverPrefix(expr ast.Expr, prefixes map[string]string) string {
-	if ident, ok := expr.(*ast.Ident); ok {
+	if ident, ok := routerReceiver(expr).(*ast.Ident); ok {
 		return prefixes[ident.Name]
 	}
 	return ""
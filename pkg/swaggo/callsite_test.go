@@ -4,6 +4,7 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"strings"
 	"testing"
 )
 
@@ -629,3 +630,69 @@ func extractParamType(t *testing.T, p *Parser, src string) string {
 	t.Fatal("no function param found in source")
 	return ""
 }
+
+func TestIndirectRegistrarPrefix(t *testing.T) {
+	p := analyzeFiles(t, map[string]string{
+		"users/routes.go": `package users
+
+import "github.com/gin-gonic/gin"
+
+func RegisterRoutes(r gin.IRouter) {
+	r.GET("/users", List)
+}
+
+func List(c *gin.Context) {}
+`,
+		"orders/routes.go": `package orders
+
+import "github.com/gin-gonic/gin"
+
+type Handler struct{}
+
+func NewHandler() *Handler { return &Handler{} }
+
+func (h *Handler) RegisterRoutes(r gin.IRouter) {
+	r.GET("/orders", h.List)
+}
+
+func (h *Handler) List(c *gin.Context) {}
+`,
+		"main.go": `package main
+
+import (
+	"github.com/gin-gonic/gin"
+	"example.com/app/orders"
+	"example.com/app/users"
+)
+
+func SetupRoutes(r gin.IRouter, registrars ...func(gin.IRouter)) {
+	v1 := r.Group("/v1")
+	for _, reg := range registrars {
+		reg(v1)
+	}
+}
+
+func main() {
+	r := gin.Default()
+	api := r.Group("/api")
+	h := orders.NewHandler()
+	SetupRoutes(api, users.RegisterRoutes, h.RegisterRoutes)
+}
+`,
+	})
+
+	for _, key := range []string{"GET /api/v1/users", "GET /api/v1/orders"} {
+		method, path, _ := strings.Cut(key, " ")
+		if route := findRoute(p, method, path); route == nil || route.Handler == nil {
+			for _, route := range p.Routes {
+				t.Logf("  found: %s %s -> %s", route.Method, route.Path, route.HandlerName)
+			}
+			t.Errorf("expected route %s", key)
+		}
+	}
+	for _, route := range p.Routes {
+		if route.Path == "/users" || route.Path == "/orders" {
+			t.Errorf("unexpected unprefixed route %s %s", route.Method, route.Path)
+		}
+	}
+}
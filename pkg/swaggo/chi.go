@@ -0,0 +1,286 @@
+package swaggo
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// parseChiRouteCall 解析 go-chi 的路由註冊，handler 為 net/http 形式
+//
+//	r.Get("/users/{id}", h.Get)
+//	r.With(mw).Post("/users", h.Create)
+//	r.Method("PUT", "/users/{id}", handler)
+func (p *Parser) parseChiRouteCall(call *ast.CallExpr, sel *ast.SelectorExpr) []*RouteCall {
+	rc := &RouteCall{}
+	var handler ast.Expr
+
+	switch method := sel.Sel.Name; method {
+	case "Get", "Post", "Put", "Delete", "Patch", "Head", "Options", "Connect", "Trace":
+		if len(call.Args) != 2 {
+			return nil
+		}
+		rc.Method = strings.ToUpper(method)
+		rc.Path = p.extractStringArg(call.Args[0])
+		handler = call.Args[1]
+	case "Method", "MethodFunc":
+		if len(call.Args) != 3 {
+			return nil
+		}
+		rc.Method = p.extractHTTPMethodArg(call.Args[0])
+		rc.Path = p.extractStringArg(call.Args[1])
+		handler = call.Args[2]
+	case "Handle", "HandleFunc":
+		if len(call.Args) != 2 {
+			return nil
+		}
+		rc.Method = "Any"
+		rc.Path = p.extractStringArg(call.Args[0])
+		handler = call.Args[1]
+	default:
+		return nil
+	}
+
+	if rc.Method == "" {
+		return nil
+	}
+
+	var middlewares []ast.Expr
+	rc.Handler, middlewares = p.unwrapNetHTTPHandler(handler)
+	rc.Middlewares = append(chiWithArgs(sel.X), middlewares...)
+	return []*RouteCall{rc}
+}
+
+// routerReceiver 去掉 chi 的 With(...) 與 gorilla/mux 的路由鏈，取得實際的 router 運算式
+// 例如：r.With(auth).With(log) → r、r.HandleFunc(path, h).Methods("GET") → r
+func routerReceiver(expr ast.Expr) ast.Expr {
+	for {
+		call, ok := expr.(*ast.CallExpr)
+		if !ok {
+			return expr
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || (sel.Sel.Name != "With" && !isGorillaRouteChainMethod(sel.Sel.Name)) {
+			return expr
+		}
+		expr = sel.X
+	}
+}
+
+// chiWithArgs 收集 With(...) 鏈上的 middleware，由外層 router 往路由方向排列
+func chiWithArgs(expr ast.Expr) []ast.Expr {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "With" {
+		return nil
+	}
+	return append(chiWithArgs(sel.X), call.Args...)
+}
+
+// enterChiScope 處理 r.Route("/users", func(r chi.Router) {...}) 與 r.Group(func(r chi.Router) {...})
+// 回傳閉包 body 以及加入閉包參數後的 prefix / middleware scope；不是子路由呼叫時 body 為 nil
+func (p *Parser) enterChiScope(call *ast.CallExpr, pkgName string, groupPrefixes map[string]string, groupMiddlewares map[string][]*MiddlewareInfo) (*ast.BlockStmt, map[string]string, map[string][]*MiddlewareInfo) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	var prefix string
+	var fn *ast.FuncLit
+	switch sel.Sel.Name {
+	case "Route":
+		if len(call.Args) == 2 {
+			prefix = p.extractStringArg(call.Args[0])
+			fn, _ = call.Args[1].(*ast.FuncLit)
+		}
+	case "Group":
+		if len(call.Args) == 1 {
+			fn, _ = call.Args[0].(*ast.FuncLit)
+		}
+	}
+	if fn == nil || fn.Body == nil || fn.Type.Params == nil || len(fn.Type.Params.List) == 0 || len(fn.Type.Params.List[0].Names) == 0 {
+		return nil, nil, nil
+	}
+	param := fn.Type.Params.List[0].Names[0].Name
+
+	prefixes := make(map[string]string, len(groupPrefixes)+1)
+	for k, v := range groupPrefixes {
+		prefixes[k] = v
+	}
+	prefixes[param] = p.getReceiverPrefix(sel.X, groupPrefixes) + prefix
+
+	middlewares := make(map[string][]*MiddlewareInfo, len(groupMiddlewares)+1)
+	for k, v := range groupMiddlewares {
+		middlewares[k] = v
+	}
+	inherited := p.resolveGroupMiddlewares(sel.X, groupMiddlewares)
+	middlewares[param] = appendMiddlewares(inherited, p.resolveMiddlewares(chiWithArgs(sel.X), pkgName)...)
+
+	return fn.Body, prefixes, middlewares
+}
+
+// inspectRouterScopes 類似 ast.Inspect，但遇到 chi 的 Route/Group 閉包時
+// 會以閉包自己的 prefix / middleware scope 遞迴，避免內外層同名的 r 互相覆蓋
+func (p *Parser) inspectRouterScopes(node ast.Node, pkgName string, framework Framework, groupPrefixes map[string]string, groupMiddlewares map[string][]*MiddlewareInfo, visit func(n ast.Node, groupPrefixes map[string]string, groupMiddlewares map[string][]*MiddlewareInfo) bool) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if ok && framework.Name() == FrameworkChi {
+			if body, prefixes, middlewares := p.enterChiScope(call, pkgName, groupPrefixes, groupMiddlewares); body != nil {
+				p.inspectRouterScopes(body, pkgName, framework, prefixes, middlewares, visit)
+				return false
+			}
+		}
+		// gorilla/mux 的路由鏈只在最外層呼叫解析一次，不再走進內層的 HandleFunc
+		if ok && framework.Name() == FrameworkGorilla && p.parseGorillaChain(call) != nil {
+			visit(n, groupPrefixes, groupMiddlewares)
+			return false
+		}
+		return visit(n, groupPrefixes, groupMiddlewares)
+	})
+}
+
+// chiMountedCall 解析 r.Mount("/admin", adminRouter())，回傳掛載的 prefix 與建構 router 的呼叫
+func (p *Parser) chiMountedCall(call *ast.CallExpr) (string, *ast.SelectorExpr, *ast.CallExpr) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Mount" || len(call.Args) != 2 {
+		return "", nil, nil
+	}
+	inner, ok := call.Args[1].(*ast.CallExpr)
+	if !ok {
+		return "", nil, nil
+	}
+	return strings.TrimSuffix(p.extractStringArg(call.Args[0]), "/"), sel, inner
+}
+
+// tryBuildMountCallSite 把 r.Mount("/admin", adminRouter()) 視為 adminRouter 這個 registrar 的呼叫點
+func (p *Parser) tryBuildMountCallSite(call *ast.CallExpr, pkgName string, groupPrefixes map[string]string, groupMiddlewares map[string][]*MiddlewareInfo, registrars map[string]*RouteRegistrar) *CallSite {
+	prefix, sel, inner := p.chiMountedCall(call)
+	if inner == nil {
+		return nil
+	}
+
+	funcName, _ := p.extractCallInfo(inner, pkgName)
+	reg := p.matchRegistrar(funcName, registrars)
+	if reg == nil {
+		return nil
+	}
+
+	return &CallSite{
+		Registrar:   reg,
+		GroupPrefix: p.getReceiverPrefix(sel.X, groupPrefixes) + prefix,
+		Middlewares: p.resolveGroupMiddlewares(sel.X, groupMiddlewares),
+		conditions:  p.guardConditions(call.Pos()),
+	}
+}
+
+// getChiParamType 檢查參數是否為 chi 路由相關型別：chi.Router, *chi.Mux
+func getChiParamType(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == "chi" {
+		switch sel.Sel.Name {
+		case "Router", "Mux":
+			return sel.Sel.Name
+		}
+	}
+	return ""
+}
+
+// chiRouterVar 偵測自行建立 router 並回傳的函數，例如：
+//
+//	func adminRouter() http.Handler {
+//		r := chi.NewRouter()
+//		r.Get("/stats", stats)
+//		return r
+//	}
+//
+// 回傳函數內的 router 變數名
+func chiRouterVar(fn *ast.FuncDecl) string {
+	if fn.Body == nil || fn.Type.Results == nil || len(fn.Type.Results.List) == 0 {
+		return ""
+	}
+	result := fn.Type.Results.List[0].Type
+	if getChiParamType(result) == "" && !isNetHTTPHandlerType(result) {
+		return ""
+	}
+
+	for _, stmt := range fn.Body.List {
+		assign, ok := stmt.(*ast.AssignStmt)
+		if !ok || assign.Tok != token.DEFINE || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+			continue
+		}
+		call, ok := assign.Rhs[0].(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || (sel.Sel.Name != "NewRouter" && sel.Sel.Name != "NewMux") {
+			continue
+		}
+		if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != "chi" {
+			continue
+		}
+		if ident, ok := assign.Lhs[0].(*ast.Ident); ok {
+			return ident.Name
+		}
+	}
+	return ""
+}
+
+// extractStandaloneRouters 解析沒有被任何地方呼叫或掛載的 router 建構函數（例如只在外部 main 使用的 NewRouter）
+func (p *Parser) extractStandaloneRouters() {
+	called := make(map[string]bool)
+	for _, file := range p.files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			switch fn := call.Fun.(type) {
+			case *ast.Ident:
+				called[fn.Name] = true
+			case *ast.SelectorExpr:
+				called[fn.Sel.Name] = true
+			}
+			return true
+		})
+	}
+
+	for _, reg := range p.routeRegistrars {
+		if reg.ParamType == "NewRouter" && !called[reg.Name] {
+			p.extractRoutesWithPrefix(reg, "")
+		}
+	}
+}
+
+// chiFramework 內建 go-chi adapter，handler 沿用 net/http 簽名
+type chiFramework struct{ p *Parser }
+
+func (f *chiFramework) Name() string { return FrameworkChi }
+
+func (f *chiFramework) Match(importPaths []string) bool {
+	return hasImport(importPaths, "github.com/go-chi/chi")
+}
+
+func (f *chiFramework) ParseRoute(call *ast.CallExpr) []*RouteCall {
+	return f.p.parseChiRouteCall(call, call.Fun.(*ast.SelectorExpr))
+}
+
+// ParseGroup chi 的 Route/Group 以閉包表示子路由，由 enterChiScope 處理
+func (f *chiFramework) ParseGroup(call *ast.CallExpr) *GroupCall { return nil }
+
+func (f *chiFramework) RouterParamType(expr ast.Expr) string { return getChiParamType(expr) }
+
+func (f *chiFramework) IsHandler(fn *ast.FuncType) bool { return f.p.isNetHTTPHandler(fn) }
+
+func (f *chiFramework) IsHandlerType(expr ast.Expr) bool { return isNetHTTPHandlerType(expr) }
+
+func (f *chiFramework) ContextMethods() map[string]ContextMethod { return nil }
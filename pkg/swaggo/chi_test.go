@@ -0,0 +1,190 @@
+package swaggo
+
+import (
+	"testing"
+)
+
+func TestChiRoutes(t *testing.T) {
+	src := `package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/jwtauth/v5"
+)
+
+type User struct {
+	ID   int    ` + "`json:\"id\"`" + `
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type UserHandler struct{}
+
+func (h *UserHandler) List(w http.ResponseWriter, r *http.Request) {
+	_ = r.URL.Query().Get("page")
+	json.NewEncoder(w).Encode([]User{})
+}
+
+func (h *UserHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	_ = id
+	json.NewEncoder(w).Encode(User{})
+}
+
+func (h *UserHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func Health(w http.ResponseWriter, r *http.Request) {}
+func Stats(w http.ResponseWriter, r *http.Request)  {}
+
+func adminRouter() http.Handler {
+	r := chi.NewRouter()
+	r.Get("/stats", Stats)
+	return r
+}
+
+func main() {
+	h := &UserHandler{}
+	tokenAuth := jwtauth.New("HS256", []byte("secret"), nil)
+
+	r := chi.NewRouter()
+	r.Use(middleware.Logger)
+	r.Get("/health", Health)
+
+	r.Route("/users", func(r chi.Router) {
+		r.Get("/", h.List)
+		r.Route("/{id:[0-9]+}", func(r chi.Router) {
+			r.Get("/", h.Get)
+			r.With(jwtauth.Verifier(tokenAuth)).Delete("/", h.Delete)
+		})
+	})
+
+	r.Mount("/admin", adminRouter())
+
+	http.ListenAndServe(":3000", r)
+}
+`
+	p := analyzeSource(t, src)
+
+	expected := map[string]string{
+		"GET:/health":        "main.Health",
+		"GET:/users/":        "main.UserHandler.List",
+		"GET:/users/:id/":    "main.UserHandler.Get",
+		"DELETE:/users/:id/": "main.UserHandler.Delete",
+		"GET:/admin/stats":   "main.Stats",
+	}
+	if len(p.Routes) != len(expected) {
+		for _, route := range p.Routes {
+			t.Logf("  found: %s %s -> %s", route.Method, route.Path, route.HandlerName)
+		}
+		t.Fatalf("expected %d routes, got %d", len(expected), len(p.Routes))
+	}
+	for key, handler := range expected {
+		found := false
+		for _, route := range p.Routes {
+			if route.Method+":"+route.Path == key {
+				found = true
+				if route.HandlerName != handler {
+					t.Errorf("route %s: handler = %q, want %q", key, route.HandlerName, handler)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("expected route %s not found", key)
+		}
+	}
+
+	del := findRoute(p, "DELETE", "/users/:id/")
+	if len(del.Middlewares) != 2 {
+		t.Errorf("expected Logger and Verifier middlewares, got %+v", del.Middlewares)
+	}
+	if len(del.Security) != 1 || del.Security[0].BearerFormat != "JWT" {
+		t.Errorf("expected JWT bearer security on DELETE, got %+v", del.Security)
+	}
+	if get := findRoute(p, "GET", "/users/:id/"); len(get.Security) != 0 {
+		t.Errorf("With() middleware should not leak to sibling routes, got %+v", get.Security)
+	}
+
+	gen := New()
+	gen.parser = p
+	spec, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("generate error: %v", err)
+	}
+
+	op := spec.Paths["/users/{id}/"].Get
+	if op == nil {
+		t.Fatal("expected GET /users/{id}/")
+	}
+	if len(op.Parameters) != 1 || op.Parameters[0].Name != "id" || op.Parameters[0].In != "path" {
+		t.Fatalf("expected single path param id, got %+v", op.Parameters)
+	}
+	if op.Parameters[0].Schema.Pattern != "^[0-9]+$" {
+		t.Errorf("pattern = %q, want %q", op.Parameters[0].Schema.Pattern, "^[0-9]+$")
+	}
+}
+
+func TestChiRegistrar(t *testing.T) {
+	src := `package routes
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func RegisterOrderRoutes(r chi.Router) {
+	r.Get("/orders", ListOrders)
+}
+
+func Setup(r *chi.Mux) {
+	r.Route("/api", func(r chi.Router) {
+		RegisterOrderRoutes(r)
+	})
+}
+
+func ListOrders(w http.ResponseWriter, r *http.Request) {}
+`
+	p := analyzeSource(t, src)
+
+	if findRoute(p, "GET", "/api/orders") == nil {
+		t.Error("expected route GET /api/orders")
+		for _, route := range p.Routes {
+			t.Logf("  found: %s %s -> %s", route.Method, route.Path, route.HandlerName)
+		}
+	}
+}
+
+func TestConvertBracePath(t *testing.T) {
+	tests := []struct {
+		input    string
+		path     string
+		patterns map[string]string
+	}{
+		{"/users/{id}", "/users/:id", nil},
+		{"/users/{id:[0-9]+}/posts/{slug}", "/users/:id/posts/:slug", map[string]string{"id": "^[0-9]+$"}},
+		{"/codes/{code:[A-Z]{3}}", "/codes/:code", map[string]string{"code": "^[A-Z]{3}$"}},
+		{"/files/{path...}", "/files/*path", nil},
+		{"/{$}", "/", nil},
+	}
+
+	for _, tt := range tests {
+		path, patterns := convertBracePath(tt.input)
+		if path != tt.path {
+			t.Errorf("convertBracePath(%q) path = %q, want %q", tt.input, path, tt.path)
+		}
+		if len(patterns) != len(tt.patterns) {
+			t.Errorf("convertBracePath(%q) patterns = %v, want %v", tt.input, patterns, tt.patterns)
+			continue
+		}
+		for name, pattern := range tt.patterns {
+			if patterns[name] != pattern {
+				t.Errorf("convertBracePath(%q) patterns[%s] = %q, want %q", tt.input, name, patterns[name], pattern)
+			}
+		}
+	}
+}
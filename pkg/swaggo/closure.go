@@ -12,6 +12,7 @@ type ClosureFactory struct {
 	FullName string
 	FuncDecl *ast.FuncDecl
 	Closure  *ast.FuncLit
+	Returns  string // 回傳具名 handler 函數時為該函數的完整名稱，例如 main.listUsers
 }
 
 // collectClosureFactories 收集所有返回 gin.HandlerFunc 的工廠函數
@@ -37,7 +38,7 @@ func (p *Parser) collectFactoriesFromFile(file *ast.File, pkgName string, factor
 			return true
 		}
 
-		closure := p.findReturnedClosure(fn)
+		closure, returns := p.findReturnedClosure(fn, pkgName)
 		if closure == nil {
 			return true
 		}
@@ -49,6 +50,7 @@ func (p *Parser) collectFactoriesFromFile(file *ast.File, pkgName string, factor
 			FullName: fullName,
 			FuncDecl: fn,
 			Closure:  closure,
+			Returns:  returns,
 		}
 
 		return true
@@ -61,33 +63,36 @@ func (p *Parser) returnsGinHandlerFunc(results *ast.FieldList) bool {
 	}
 
 	for _, result := range results.List {
-		if p.isGinHandlerFunc(result.Type) {
+		if p.isHandlerType(result.Type) {
+			return true
+		}
+		// 未具名的函數型別，例如 func(*gin.Context)
+		if ft, ok := result.Type.(*ast.FuncType); ok && p.isHandlerFuncType(ft) {
 			return true
 		}
 	}
 	return false
 }
 
-func (p *Parser) isGinHandlerFunc(expr ast.Expr) bool {
-	sel, ok := expr.(*ast.SelectorExpr)
-	if !ok {
-		return false
-	}
-
-	ident, ok := sel.X.(*ast.Ident)
-	if !ok {
-		return false
+// isHandlerFuncType 判斷函數型別是否為任一框架的 handler 簽名
+func (p *Parser) isHandlerFuncType(ft *ast.FuncType) bool {
+	for _, fw := range p.frameworks {
+		if fw.IsHandler(ft) {
+			return true
+		}
 	}
-
-	return ident.Name == "gin" && sel.Sel.Name == "HandlerFunc"
+	return false
 }
 
-func (p *Parser) findReturnedClosure(fn *ast.FuncDecl) *ast.FuncLit {
+// findReturnedClosure 找出工廠回傳的 handler：閉包，或具名的 handler 函數（例如 return listUsers）
+// 回傳具名函數時一併回傳其完整名稱
+func (p *Parser) findReturnedClosure(fn *ast.FuncDecl, pkgName string) (*ast.FuncLit, string) {
 	if fn.Body == nil {
-		return nil
+		return nil, ""
 	}
 
 	var closure *ast.FuncLit
+	var returns string
 
 	ast.Inspect(fn.Body, func(n ast.Node) bool {
 		ret, ok := n.(*ast.ReturnStmt)
@@ -96,15 +101,24 @@ func (p *Parser) findReturnedClosure(fn *ast.FuncDecl) *ast.FuncLit {
 		}
 
 		for _, result := range ret.Results {
-			if funcLit, ok := result.(*ast.FuncLit); ok {
-				closure = funcLit
+			switch r := result.(type) {
+			case *ast.FuncLit:
+				closure = r
 				return false
+			case *ast.Ident, *ast.SelectorExpr:
+				name := p.resolveHandlerName(r, pkgName)
+				named := p.findFuncDecl(name)
+				if named != nil && named != fn && named.Body != nil && p.isHandlerFuncType(named.Type) {
+					closure = &ast.FuncLit{Type: named.Type, Body: named.Body}
+					returns = name
+					return false
+				}
 			}
 		}
 		return true
 	})
 
-	return closure
+	return closure, returns
 }
 
 func (p *Parser) analyzeClosureAsHandler(factory *ClosureFactory) *HandlerInfo {
@@ -117,10 +131,11 @@ func (p *Parser) analyzeClosureAsHandler(factory *ClosureFactory) *HandlerInfo {
 		FullName:  factory.FullName,
 		Package:   factory.Package,
 		Responses: make(map[int]*ResponseInfo),
+		pos:       factory.Closure.Pos(),
 	}
 
 	p.extractDocComment(factory.FuncDecl.Doc, handler)
-	p.analyzeClosureBody(factory.Closure, handler)
+	p.analyzeClosureBody(factory.Closure, handler, nil)
 
 	return handler
 }
@@ -139,14 +154,20 @@ func (p *Parser) extractDocComment(doc *ast.CommentGroup, handler *HandlerInfo)
 	}
 }
 
-func (p *Parser) analyzeClosureBody(closure *ast.FuncLit, handler *HandlerInfo) {
+// analyzeClosureBody 分析閉包內容，argTypes 為特化時工廠參數對應的型別
+func (p *Parser) analyzeClosureBody(closure *ast.FuncLit, handler *HandlerInfo, argTypes map[string]string) {
 	if closure.Body == nil {
 		return
 	}
 
 	localVarTypes := p.collectLocalVarTypes(closure.Body.List)
+	for name, typeName := range argTypes {
+		if _, shadowed := localVarTypes[name]; !shadowed {
+			localVarTypes[name] = typeName
+		}
+	}
 
-	ast.Inspect(closure.Body, func(n ast.Node) bool {
+	p.inspectHandlerBody(closure.Body, handler, func(n ast.Node) bool {
 		call, ok := n.(*ast.CallExpr)
 		if !ok {
 			return true
@@ -155,6 +176,9 @@ func (p *Parser) analyzeClosureBody(closure *ast.FuncLit, handler *HandlerInfo)
 		p.analyzeGinCall(call, handler, localVarTypes)
 		return true
 	})
+
+	p.analyzeNetHTTPResponses(closure.Body, handler, localVarTypes)
+	p.collectMuxVars(closure.Body, handler)
 }
 
 func (p *Parser) collectLocalVarTypes(stmts []ast.Stmt) map[string]string {
@@ -207,19 +231,61 @@ func (p *Parser) analyzeGinCall(call *ast.CallExpr, handler *HandlerInfo, localV
 		return
 	}
 
+	// 自訂框架宣告的 context 方法優先
+	if cm, ok := p.contextMethod(call, sel.Sel.Name); ok {
+		p.applyContextMethod(call, cm, handler, localVarTypes)
+		return
+	}
+
+	if resp := p.fiberResponse(call, sel, localVarTypes); resp != nil {
+		handler.Responses[resp.StatusCode] = resp
+		return
+	}
+
 	switch sel.Sel.Name {
-	case "Param":
+	case "Param", "Params", "PathValue":
 		p.addParamFromCall(call, handler, "path", true)
-	case "Query":
+	case "Query", "QueryParam":
 		p.addQueryParam(call, handler)
+	case "FormValue":
+		p.addParamFromCall(call, handler, "formData", false)
+	case "URLParam":
+		// chi.URLParam(r, "id")
+		if len(call.Args) >= 2 {
+			if name := p.extractStringArg(call.Args[1]); name != "" {
+				handler.Parameters = append(handler.Parameters, &ParameterInfo{
+					Name:     name,
+					Type:     "string",
+					In:       "path",
+					Required: true,
+				})
+			}
+		}
 	case "DefaultQuery":
 		p.addDefaultQueryParam(call, handler)
 	case "GetHeader":
 		p.addParamFromCall(call, handler, "header", false)
-	case "ShouldBindJSON", "BindJSON", "ShouldBind", "Bind":
+	case "Get":
+		if isHeaderGet(sel) {
+			p.addParamFromCall(call, handler, "header", false)
+		}
+		if isURLQueryGet(sel) {
+			p.addQueryParam(call, handler)
+		}
+	case "Decode":
+		if isJSONDecoderCall(sel.X) {
+			p.addRequestBody(call, handler, localVarTypes)
+		}
+	case "ShouldBindJSON", "BindJSON", "ShouldBind", "Bind", "BodyParser":
 		p.addRequestBody(call, handler, localVarTypes)
 	case "JSON":
 		p.addJSONResponse(call, handler, localVarTypes)
+	case "NoContent":
+		if len(call.Args) > 0 {
+			if statusCode := p.extractStatusCode(call.Args[0]); statusCode > 0 {
+				handler.Responses[statusCode] = &ResponseInfo{StatusCode: statusCode}
+			}
+		}
 	}
 }
 
@@ -0,0 +1,209 @@
+package swaggo
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// guardConditions 回傳 pos 所在位置外層的 if / switch 條件，由外而內，例如：
+//
+//	if cfg.Features.Beta { ... }                   → cfg.Features.Beta
+//	if gin.Mode() == gin.DebugMode {} else { ... } → gin.Mode() != gin.DebugMode
+//	switch env { case "dev", "staging": ... }      → env == "dev" || env == "staging"
+func (p *Parser) guardConditions(pos token.Pos) []string {
+	file := p.fileAt(pos)
+	if file == nil {
+		return nil
+	}
+
+	var conds []string
+	ast.Inspect(file, func(n ast.Node) bool {
+		if n == nil || !containsPos(n, pos) {
+			return false
+		}
+		switch node := n.(type) {
+		case *ast.IfStmt:
+			cond := types.ExprString(node.Cond)
+			if containsPos(node.Body, pos) {
+				conds = append(conds, cond)
+			} else if node.Else != nil && containsPos(node.Else, pos) {
+				conds = append(conds, negateCondition(node.Cond))
+			}
+		case *ast.SwitchStmt:
+			if cond := switchCondition(node, pos); cond != "" {
+				conds = append(conds, cond)
+			}
+		}
+		return true
+	})
+	return conds
+}
+
+// fileAt 找出包含 pos 的檔案
+func (p *Parser) fileAt(pos token.Pos) *ast.File {
+	if !pos.IsValid() {
+		return nil
+	}
+	for _, file := range p.files {
+		if pos >= file.FileStart && pos <= file.FileEnd {
+			return file
+		}
+	}
+	return nil
+}
+
+func containsPos(n ast.Node, pos token.Pos) bool {
+	return pos >= n.Pos() && pos < n.End()
+}
+
+// negateCondition 產生 else 分支的條件，== 與 != 直接互換
+func negateCondition(cond ast.Expr) string {
+	switch e := cond.(type) {
+	case *ast.BinaryExpr:
+		switch e.Op {
+		case token.EQL:
+			return types.ExprString(e.X) + " != " + types.ExprString(e.Y)
+		case token.NEQ:
+			return types.ExprString(e.X) + " == " + types.ExprString(e.Y)
+		}
+	case *ast.UnaryExpr:
+		if e.Op == token.NOT {
+			return types.ExprString(e.X)
+		}
+	case *ast.Ident, *ast.SelectorExpr, *ast.CallExpr, *ast.ParenExpr:
+		return "!" + types.ExprString(cond)
+	}
+	return "!(" + types.ExprString(cond) + ")"
+}
+
+// switchCondition 回傳 pos 所在 case 的條件；default 分支為其他 case 條件的否定
+func switchCondition(sw *ast.SwitchStmt, pos token.Pos) string {
+	var current *ast.CaseClause
+	var others []string
+	for _, stmt := range sw.Body.List {
+		clause, ok := stmt.(*ast.CaseClause)
+		if !ok {
+			continue
+		}
+		if containsPos(clause, pos) {
+			current = clause
+			continue
+		}
+		if cond := caseCondition(sw.Tag, clause); cond != "" {
+			others = append(others, cond)
+		}
+	}
+	if current == nil {
+		return ""
+	}
+	if current.List != nil {
+		return caseCondition(sw.Tag, current)
+	}
+	if len(others) == 0 {
+		return ""
+	}
+	return "!(" + strings.Join(others, " || ") + ")"
+}
+
+func caseCondition(tag ast.Expr, clause *ast.CaseClause) string {
+	var parts []string
+	for _, value := range clause.List {
+		if tag == nil {
+			parts = append(parts, types.ExprString(value))
+		} else {
+			parts = append(parts, types.ExprString(tag)+" == "+types.ExprString(value))
+		}
+	}
+	return strings.Join(parts, " || ")
+}
+
+// withCallConditions 執行 extract，並把呼叫點外層的條件加在期間新增的路由前面
+func (p *Parser) withCallConditions(conds []string, extract func()) {
+	start := len(p.Routes)
+	extract()
+	if len(conds) == 0 {
+		return
+	}
+	for _, route := range p.Routes[start:] {
+		route.Conditions = append(append([]string{}, conds...), route.Conditions...)
+	}
+}
+
+// applyRouteConditions 補上路由註冊位置本身的條件，並依 include / exclude 規則過濾路由
+func (p *Parser) applyRouteConditions() {
+	routes := p.Routes[:0]
+	for _, route := range p.Routes {
+		route.Conditions = append(route.Conditions, p.guardConditions(route.pos)...)
+		if p.conditionExcluded(route) {
+			continue
+		}
+		routes = append(routes, route)
+	}
+	p.Routes = routes
+}
+
+// conditionExcluded 任一條件符合 exclude 規則即排除；
+// 設定 include 規則時，有條件的路由至少要有一個條件符合，沒有條件的路由一律保留
+func (p *Parser) conditionExcluded(route *RouteInfo) bool {
+	for _, cond := range route.Conditions {
+		if matchConditionPatterns(p.excludeConditions, cond) {
+			return true
+		}
+	}
+	if len(p.includeConditions) == 0 || len(route.Conditions) == 0 {
+		return false
+	}
+	for _, cond := range route.Conditions {
+		if matchConditionPatterns(p.includeConditions, cond) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchConditionPatterns 以 * 萬用字元比對整個條件字串，例如 gin.Mode()* 或 *ENABLE_ADMIN*
+func matchConditionPatterns(patterns []string, cond string) bool {
+	for _, pattern := range patterns {
+		if matchWildcard(pattern, cond) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchWildcard(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+	return len(s) >= len(last) && strings.HasSuffix(s, last)
+}
+
+// conditionExpression 把多個條件以 && 串接成 x-condition 的值
+func conditionExpression(conds []string) string {
+	if len(conds) == 1 {
+		return conds[0]
+	}
+	parts := make([]string, len(conds))
+	for i, cond := range conds {
+		if strings.Contains(cond, " || ") && !strings.HasPrefix(cond, "!(") {
+			cond = "(" + cond + ")"
+		}
+		parts[i] = cond
+	}
+	return strings.Join(parts, " && ")
+}
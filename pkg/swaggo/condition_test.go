@@ -0,0 +1,149 @@
+package swaggo
+
+import (
+	"go/parser"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+const conditionalSource = `package main
+
+import (
+	"os"
+
+	"github.com/gin-gonic/gin"
+)
+
+func main() {
+	r := gin.Default()
+	r.GET("/health", Health)
+
+	if gin.Mode() == gin.DebugMode {
+		r.GET("/debug/vars", Health)
+	} else {
+		r.GET("/metrics", Health)
+	}
+
+	if cfg.Features.Beta {
+		registerBeta(r)
+	}
+
+	switch os.Getenv("ENV") {
+	case "dev", "staging":
+		r.GET("/seed", Health)
+	}
+}
+
+func registerBeta(r *gin.Engine) {
+	if os.Getenv("ENABLE_ADMIN") == "true" {
+		r.GET("/beta/admin", Health)
+	}
+	r.GET("/beta", Health)
+}
+
+func Health(c *gin.Context) { c.JSON(200, nil) }
+`
+
+func TestRouteConditions(t *testing.T) {
+	p := analyzeSource(t, conditionalSource)
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"/health", nil},
+		{"/debug/vars", []string{"gin.Mode() == gin.DebugMode"}},
+		{"/metrics", []string{"gin.Mode() != gin.DebugMode"}},
+		{"/beta", []string{"cfg.Features.Beta"}},
+		{"/beta/admin", []string{"cfg.Features.Beta", `os.Getenv("ENABLE_ADMIN") == "true"`}},
+		{"/seed", []string{`os.Getenv("ENV") == "dev" || os.Getenv("ENV") == "staging"`}},
+	}
+	for _, tt := range tests {
+		route := findRoute(p, "GET", tt.path)
+		if route == nil {
+			t.Fatalf("route %s not found", tt.path)
+		}
+		if !reflect.DeepEqual(route.Conditions, tt.want) {
+			t.Errorf("%s conditions = %q, want %q", tt.path, route.Conditions, tt.want)
+		}
+	}
+
+	gen := New()
+	gen.parser = p
+	spec, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	want := `cfg.Features.Beta && os.Getenv("ENABLE_ADMIN") == "true"`
+	if got := spec.Paths["/beta/admin"].Get.XCondition; got != want {
+		t.Errorf("x-condition = %q, want %q", got, want)
+	}
+	if got := spec.Paths["/health"].Get.XCondition; got != "" {
+		t.Errorf("unconditional route x-condition = %q", got)
+	}
+}
+
+func TestRouteConditionFilters(t *testing.T) {
+	analyze := func(include, exclude []string) []string {
+		p := NewParser()
+		p.includeConditions = include
+		p.excludeConditions = exclude
+		file, err := parser.ParseFile(p.fset, "main.go", conditionalSource, parser.ParseComments)
+		if err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		p.files = append(p.files, file)
+		if err := p.Analyze(); err != nil {
+			t.Fatalf("analyze error: %v", err)
+		}
+		var paths []string
+		for _, route := range p.Routes {
+			paths = append(paths, route.Path)
+		}
+		return paths
+	}
+
+	got := analyze(nil, []string{"gin.Mode() == gin.DebugMode", `*"ENV"*`, "*ENABLE_ADMIN*"})
+	for _, path := range []string{"/debug/vars", "/seed", "/beta/admin"} {
+		if slices.Contains(got, path) {
+			t.Errorf("excluded route %s still present: %v", path, got)
+		}
+	}
+	for _, path := range []string{"/health", "/metrics", "/beta"} {
+		if !slices.Contains(got, path) {
+			t.Errorf("route %s missing: %v", path, got)
+		}
+	}
+
+	got = analyze([]string{"cfg.Features.*"}, nil)
+	for _, path := range []string{"/health", "/beta", "/beta/admin"} {
+		if !slices.Contains(got, path) {
+			t.Errorf("route %s missing with include rule: %v", path, got)
+		}
+	}
+	for _, path := range []string{"/debug/vars", "/metrics", "/seed"} {
+		if slices.Contains(got, path) {
+			t.Errorf("route %s should not match include rule: %v", path, got)
+		}
+	}
+}
+
+func TestMatchWildcard(t *testing.T) {
+	tests := []struct {
+		pattern, s string
+		want       bool
+	}{
+		{"cfg.Features.Beta", "cfg.Features.Beta", true},
+		{"cfg.Features.*", "cfg.Features.Beta", true},
+		{"*DebugMode", "gin.Mode() == gin.DebugMode", true},
+		{"*ADMIN*", `os.Getenv("ENABLE_ADMIN") == "true"`, true},
+		{"cfg.*.Beta", "cfg.Features.Alpha", false},
+		{"gin.Mode()", "gin.Mode() == gin.DebugMode", false},
+	}
+	for _, tt := range tests {
+		if got := matchWildcard(tt.pattern, tt.s); got != tt.want {
+			t.Errorf("matchWildcard(%q, %q) = %v, want %v", tt.pattern, tt.s, got, tt.want)
+		}
+	}
+}
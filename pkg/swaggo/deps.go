@@ -0,0 +1,434 @@
+package swaggo
+
+import (
+	"go/ast"
+	"go/parser"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// goModule go.mod 中與依賴解析相關的內容
+type goModule struct {
+	dir      string
+	path     string
+	requires map[string]string        // module path → version
+	replaces map[string]moduleReplace // module path → 替換目標
+	uses     []string                 // go.work 的 use 目錄
+}
+
+// moduleReplace replace 指令的目標，version 為空字串表示本地目錄
+type moduleReplace struct {
+	path    string
+	version string
+}
+
+// typeRef 外部 package 中被引用的型別
+type typeRef struct {
+	importPath string
+	name       string
+}
+
+// loadDependencyTypes 解析 handler 引用的外部 module 型別（-parse-deps）
+// module 位置依序由 go.mod 的 replace、vendor 目錄與本機的 module cache 決定，不會連線下載
+func (p *Parser) loadDependencyTypes() {
+	mod := p.findGoModule()
+	if mod == nil {
+		return
+	}
+
+	queue, aliases := p.collectExternalTypeRefs(mod.path)
+	packages := make(map[string][]*ast.File)
+	loaded := make(map[typeRef]bool)
+
+	for len(queue) > 0 {
+		ref := queue[0]
+		queue = queue[1:]
+		if loaded[ref] {
+			continue
+		}
+		loaded[ref] = true
+
+		files, ok := packages[ref.importPath]
+		if !ok {
+			files = p.parseDependencyPackage(mod, ref.importPath)
+			packages[ref.importPath] = files
+		}
+
+		for _, file := range files {
+			ts := findTypeSpec(file, ref.name)
+			if ts == nil {
+				continue
+			}
+			typeInfo := p.extractTypeSpec(ts, file.Name.Name)
+			if typeInfo == nil {
+				break
+			}
+			p.Types[typeInfo.FullName] = typeInfo
+			if alias := aliases[ref.importPath]; alias != "" && alias != file.Name.Name {
+				p.Types[alias+"."+ts.Name.Name] = typeInfo
+			}
+			if _, exists := p.Types[ts.Name.Name]; !exists {
+				p.Types[ts.Name.Name] = typeInfo
+			}
+
+			// 欄位引用的型別：同 package 的型別與其他外部 package 的型別
+			imports := fileImports(file)
+			for _, field := range ts.Type.(*ast.StructType).Fields.List {
+				ast.Inspect(field.Type, func(n ast.Node) bool {
+					switch expr := n.(type) {
+					case *ast.SelectorExpr:
+						if pkg, ok := expr.X.(*ast.Ident); ok {
+							if path, ok := imports[pkg.Name]; ok && p.isDependencyImport(path, mod.path) {
+								queue = append(queue, typeRef{importPath: path, name: expr.Sel.Name})
+							}
+						}
+						return false
+					case *ast.Ident:
+						queue = append(queue, typeRef{importPath: ref.importPath, name: expr.Name})
+					}
+					return true
+				})
+			}
+			break
+		}
+	}
+}
+
+// collectExternalTypeRefs 收集 handler 與本地 struct 欄位引用的外部型別，同時回傳各 import path 在本地使用的別名
+func (p *Parser) collectExternalTypeRefs(modulePath string) ([]typeRef, map[string]string) {
+	var refs []typeRef
+	seen := make(map[typeRef]bool)
+	aliases := make(map[string]string)
+
+	for _, file := range p.files {
+		imports := fileImports(file)
+		collect := func(node ast.Node) {
+			ast.Inspect(node, func(n ast.Node) bool {
+				sel, ok := n.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+				pkg, ok := sel.X.(*ast.Ident)
+				if !ok || !isExported(sel.Sel.Name) {
+					return true
+				}
+				path, ok := imports[pkg.Name]
+				if !ok || !p.isDependencyImport(path, modulePath) {
+					return true
+				}
+				ref := typeRef{importPath: path, name: sel.Sel.Name}
+				if !seen[ref] {
+					seen[ref] = true
+					refs = append(refs, ref)
+				}
+				aliases[path] = pkg.Name
+				return true
+			})
+		}
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.FuncDecl:
+				if p.isHandlerDecl(node) {
+					collect(node)
+					return false
+				}
+			case *ast.FuncLit:
+				if p.isHandlerFuncType(node.Type) {
+					collect(node)
+					return false
+				}
+			case *ast.TypeSpec:
+				if _, ok := node.Type.(*ast.StructType); ok {
+					collect(node.Type)
+				}
+				return false
+			}
+			return true
+		})
+	}
+
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].importPath != refs[j].importPath {
+			return refs[i].importPath < refs[j].importPath
+		}
+		return refs[i].name < refs[j].name
+	})
+	return refs, aliases
+}
+
+// parseDependencyPackage 解析外部 package 目錄下的非測試檔案，找不到時回傳 nil
+// workspace 中各模組的 go.mod 依序嘗試，先以最接近的 go.mod 為準
+func (p *Parser) parseDependencyPackage(mod *goModule, importPath string) []*ast.File {
+	var dir string
+	for _, candidate := range append([]*goModule{mod}, p.localModules...) {
+		if dir = p.resolveImportDir(candidate, importPath); dir != "" {
+			break
+		}
+	}
+	if dir == "" {
+		return nil
+	}
+	pkgs, err := parser.ParseDir(p.fset, dir, func(fi os.FileInfo) bool {
+		return p.matchBuildFile(dir, fi)
+	}, parser.ParseComments)
+	if err != nil {
+		return nil
+	}
+
+	var files []*ast.File
+	for name, pkg := range pkgs {
+		if strings.HasSuffix(name, "_test") || name == "main" {
+			continue
+		}
+		for _, file := range pkg.Files {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// resolveImportDir 找出 import path 對應的本機目錄
+func (p *Parser) resolveImportDir(mod *goModule, importPath string) string {
+	modPath := ""
+	match := func(path string) {
+		if (importPath == path || strings.HasPrefix(importPath, path+"/")) && len(path) > len(modPath) {
+			modPath = path
+		}
+	}
+	for path := range mod.requires {
+		match(path)
+	}
+	for path := range mod.replaces {
+		match(path)
+	}
+	if modPath == "" {
+		return ""
+	}
+	sub := strings.TrimPrefix(importPath, modPath)
+	version := mod.requires[modPath]
+
+	if r, ok := mod.replaces[modPath]; ok {
+		if r.version == "" {
+			if dir := filepath.Join(r.path, filepath.FromSlash(sub)); p.dirExists(dir) {
+				return dir
+			}
+			return ""
+		}
+		modPath, version = r.path, r.version
+	}
+
+	if dir := filepath.Join(mod.dir, "vendor", filepath.FromSlash(importPath)); p.dirExists(dir) {
+		return dir
+	}
+
+	if version == "" {
+		return ""
+	}
+	dir := filepath.Join(moduleCacheDir(), filepath.FromSlash(escapeModulePath(modPath))+"@"+version, filepath.FromSlash(sub))
+	if p.dirExists(dir) {
+		return dir
+	}
+	return ""
+}
+
+// findGoModule 由已解析檔案所在目錄往上尋找 go.mod
+func (p *Parser) findGoModule() *goModule {
+	if len(p.files) == 0 {
+		return nil
+	}
+	dir, err := filepath.Abs(filepath.Dir(p.fset.Position(p.files[0].Pos()).Filename))
+	if err != nil {
+		return nil
+	}
+	for {
+		if mod := parseGoMod(filepath.Join(dir, "go.mod")); mod != nil {
+			return mod
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil
+		}
+		dir = parent
+	}
+}
+
+// parseGoMod 解析 go.mod（或語法相同的 go.work）的 module、require、replace 與 use，檔案不存在時回傳 nil
+func parseGoMod(path string) *goModule {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	mod := &goModule{
+		dir:      filepath.Dir(path),
+		requires: make(map[string]string),
+		replaces: make(map[string]moduleReplace),
+	}
+	block := ""
+	for _, line := range strings.Split(string(data), "\n") {
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if block != "" {
+			if fields[0] == ")" {
+				block = ""
+				continue
+			}
+			mod.addDirective(block, fields)
+			continue
+		}
+		if len(fields) == 2 && fields[1] == "(" {
+			block = fields[0]
+			continue
+		}
+		mod.addDirective(fields[0], fields[1:])
+	}
+	return mod
+}
+
+func (m *goModule) addDirective(verb string, args []string) {
+	for i := range args {
+		args[i] = strings.Trim(args[i], `"`)
+	}
+	switch verb {
+	case "module":
+		if len(args) > 0 {
+			m.path = args[0]
+		}
+	case "require":
+		if len(args) >= 2 {
+			m.requires[args[0]] = args[1]
+		}
+	case "replace":
+		// old [version] => new [version]，new 沒有版本時為本地目錄
+		arrow := -1
+		for i, arg := range args {
+			if arg == "=>" {
+				arrow = i
+			}
+		}
+		if arrow < 1 || arrow+1 >= len(args) {
+			return
+		}
+		target := args[arrow+1:]
+		r := moduleReplace{path: target[0]}
+		if len(target) > 1 {
+			r.version = target[1]
+		} else if !filepath.IsAbs(r.path) {
+			r.path = filepath.Join(m.dir, filepath.FromSlash(r.path))
+		}
+		m.replaces[args[0]] = r
+	case "use":
+		if len(args) > 0 {
+			dir := filepath.FromSlash(args[0])
+			if !filepath.IsAbs(dir) {
+				dir = filepath.Join(m.dir, dir)
+			}
+			m.uses = append(m.uses, dir)
+		}
+	}
+}
+
+// moduleCacheDir 回傳 GOMODCACHE，未設定時為 $GOPATH/pkg/mod
+func moduleCacheDir() string {
+	if dir := os.Getenv("GOMODCACHE"); dir != "" {
+		return dir
+	}
+	gopath := os.Getenv("GOPATH")
+	if gopath == "" {
+		home, _ := os.UserHomeDir()
+		gopath = filepath.Join(home, "go")
+	}
+	return filepath.Join(filepath.SplitList(gopath)[0], "pkg", "mod")
+}
+
+// escapeModulePath 依 module cache 的規則把大寫字母轉成 !小寫，例如 Acme → !acme
+func escapeModulePath(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		if unicode.IsUpper(r) {
+			b.WriteByte('!')
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+var majorVersionSuffix = regexp.MustCompile(`^v[0-9]+$`)
+
+// fileImports 回傳檔案中 import 別名 → import path，未指定別名時以路徑最後一段推斷
+func fileImports(file *ast.File) map[string]string {
+	imports := make(map[string]string)
+	for _, imp := range file.Imports {
+		path := strings.Trim(imp.Path.Value, `"`)
+		if imp.Name != nil {
+			if imp.Name.Name != "_" && imp.Name.Name != "." {
+				imports[imp.Name.Name] = path
+			}
+			continue
+		}
+		imports[importPackageName(path)] = path
+	}
+	return imports
+}
+
+// importPackageName 由 import path 推測 package 名稱，例如 github.com/go-chi/chi/v5 → chi
+func importPackageName(path string) string {
+	parts := strings.Split(path, "/")
+	name := parts[len(parts)-1]
+	if majorVersionSuffix.MatchString(name) && len(parts) > 1 {
+		name = parts[len(parts)-2]
+	}
+	// gopkg.in/yaml.v3 → yaml
+	if dot := strings.Index(name, ".v"); dot > 0 {
+		name = name[:dot]
+	}
+	return strings.ReplaceAll(name, "-", "")
+}
+
+// isExternalImport 判斷 import 是否屬於其他 module（排除標準函式庫與本地 module）
+func isExternalImport(importPath, modulePath string) bool {
+	first := strings.SplitN(importPath, "/", 2)[0]
+	if !strings.Contains(first, ".") {
+		return false
+	}
+	return modulePath == "" || (importPath != modulePath && !strings.HasPrefix(importPath, modulePath+"/"))
+}
+
+// isDependencyImport 判斷 import 是否需要由 -parse-deps 載入：外部 module 且尚未被當作本地 package 解析
+func (p *Parser) isDependencyImport(importPath, modulePath string) bool {
+	if _, parsed := p.packages[importPath]; parsed {
+		return false
+	}
+	return isExternalImport(importPath, modulePath)
+}
+
+func findTypeSpec(file *ast.File, name string) *ast.TypeSpec {
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			if ts, ok := spec.(*ast.TypeSpec); ok && ts.Name.Name == name {
+				return ts
+			}
+		}
+	}
+	return nil
+}
+
+func isExported(name string) bool {
+	return name != "" && unicode.IsUpper(rune(name[0]))
+}
@@ -0,0 +1,118 @@
+package swaggo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestParseDependencyTypes(t *testing.T) {
+	root := t.TempDir()
+	cache := filepath.Join(root, "modcache")
+	t.Setenv("GOMODCACHE", cache)
+
+	writeFiles(t, root, map[string]string{
+		"app/go.mod": `module example.com/app
+
+go 1.22
+
+require (
+	github.com/acme/contracts v1.2.0
+	github.com/Acme/Money v0.3.0 // indirect
+)
+
+replace github.com/acme/contracts => ../contracts
+`,
+		"app/main.go": `package main
+
+import (
+	"github.com/acme/contracts/users"
+	"github.com/gin-gonic/gin"
+)
+
+func CreateUser(c *gin.Context) {
+	var req users.CreateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return
+	}
+	c.JSON(201, users.User{})
+}
+
+func main() {
+	r := gin.Default()
+	r.POST("/users", CreateUser)
+}
+`,
+		"contracts/users/users.go": `package users
+
+import money "github.com/Acme/Money"
+
+type CreateUserRequest struct {
+	Name    string ` + "`json:\"name\" binding:\"required\"`" + `
+	Balance money.Amount ` + "`json:\"balance\"`" + `
+}
+
+type User struct {
+	ID      int     ` + "`json:\"id\"`" + `
+	Profile Profile ` + "`json:\"profile\"`" + `
+}
+
+type Profile struct {
+	Bio string ` + "`json:\"bio\"`" + `
+}
+
+type Unused struct {
+	X int
+}
+`,
+		"modcache/github.com/!acme/!money@v0.3.0/money.go": `package money
+
+type Amount struct {
+	Currency string ` + "`json:\"currency\"`" + `
+	Cents    int64  ` + "`json:\"cents\"`" + `
+}
+`,
+	})
+
+	p := NewParser()
+	p.parseDependency = true
+	if err := p.ParseDir(filepath.Join(root, "app")); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Analyze(); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, fields := range map[string]int{
+		"users.CreateUserRequest": 2,
+		"users.User":              2,
+		"users.Profile":           1,
+		"money.Amount":            2,
+	} {
+		typeInfo := p.Types[name]
+		if typeInfo == nil || len(typeInfo.Fields) != fields {
+			t.Errorf("expected %s with %d fields, got %+v", name, fields, typeInfo)
+		}
+	}
+	if _, ok := p.Types["users.Unused"]; ok {
+		t.Error("unreferenced dependency type should not be loaded")
+	}
+
+	route := findRoute(p, "POST", "/users")
+	if route == nil || route.Handler == nil || route.Handler.RequestBody == nil || len(route.Handler.RequestBody.Fields) != 2 {
+		t.Fatalf("expected request body resolved from dependency, got %+v", route)
+	}
+}
@@ -0,0 +1,153 @@
+package swaggo
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"sort"
+	"strings"
+)
+
+// Diagnostic 嚴重程度
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// Diagnostic code，數值固定不變，可供 CI 過濾
+const (
+	DiagUnresolvedHandler = "unresolved-handler" // 路由的 handler 找不到宣告（文件只有預設回應）或無法解析（已略過）
+	DiagUnknownBindType   = "unknown-bind-type"  // bind 的 request body 型別找不到定義，schema 沒有欄位
+	DiagEmptyRoutePath    = "empty-route-path"   // 路由 path 是空字串或不是字串字面量，已略過
+	DiagUnresolvedMethod  = "unresolved-method"  // 路由的 HTTP method 無法解析，已略過
+	DiagAnyResponse       = "any-response"       // 回應只能推斷為 any
+	DiagUnreachableRoute  = "unreachable-route"  // 路由的註冊函數無法由 main 到達，已從文件中移除
+	DiagDanglingRef       = "dangling-ref"       // schema 的 $ref 指向 components 中不存在的型別
+	DiagMissingTokenURL   = "missing-token-url"  // 推斷出 oauth2 scope 但沒有設定 token URL，scope 只輸出為 x-scopes
+)
+
+// diagnosticSeverity 每個 code 的嚴重程度
+var diagnosticSeverity = map[string]string{
+	DiagUnresolvedHandler: SeverityWarning,
+	DiagUnknownBindType:   SeverityWarning,
+	DiagEmptyRoutePath:    SeverityWarning,
+	DiagUnresolvedMethod:  SeverityWarning,
+	DiagAnyResponse:       SeverityInfo,
+	DiagUnreachableRoute:  SeverityInfo,
+	DiagParseError:        SeverityError,
+	DiagDanglingRef:       SeverityError,
+	DiagMissingTokenURL:   SeverityWarning,
+}
+
+// Diagnostic 分析過程中需要使用者留意的狀況
+type Diagnostic struct {
+	Code     string
+	Severity string
+	Message  string
+	Route    string // 相關的路由，例如 GET /users/:id；與特定路由無關時為空字串
+	Position token.Position
+}
+
+func (d Diagnostic) String() string {
+	var b strings.Builder
+	if d.Position.IsValid() {
+		b.WriteString(d.Position.String() + ": ")
+	}
+	b.WriteString(d.Severity + ": ")
+	if d.Route != "" {
+		b.WriteString(d.Route + ": ")
+	}
+	fmt.Fprintf(&b, "%s [%s]", d.Message, d.Code)
+	return b.String()
+}
+
+// MarshalJSON 以扁平的 file / line / column 輸出位置
+func (d Diagnostic) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Code     string `json:"code"`
+		Severity string `json:"severity"`
+		Message  string `json:"message"`
+		Route    string `json:"route,omitempty"`
+		File     string `json:"file,omitempty"`
+		Line     int    `json:"line,omitempty"`
+		Column   int    `json:"column,omitempty"`
+	}{d.Code, d.Severity, d.Message, d.Route, d.Position.Filename, d.Position.Line, d.Position.Column})
+}
+
+// addDiagnostic 記錄一筆 diagnostic，pos 為 token.NoPos 時不帶位置
+func (p *Parser) addDiagnostic(code string, pos token.Pos, format string, args ...any) {
+	p.addRouteDiagnostic(nil, code, pos, format, args...)
+}
+
+// addRouteDiagnostic 記錄與路由相關的 diagnostic
+func (p *Parser) addRouteDiagnostic(route *RouteInfo, code string, pos token.Pos, format string, args ...any) {
+	d := Diagnostic{Code: code, Severity: diagnosticSeverity[code], Message: fmt.Sprintf(format, args...)}
+	if route != nil {
+		d.Route = strings.ToUpper(route.Method) + " " + route.Path
+	}
+	if pos.IsValid() {
+		d.Position = p.fset.Position(pos)
+	}
+	p.appendDiagnostic(d)
+}
+
+// appendDiagnostic 同一位置的相同訊息只記錄一次
+func (p *Parser) appendDiagnostic(d Diagnostic) {
+	for _, existing := range p.Diagnostics {
+		if existing == d {
+			return
+		}
+	}
+	p.Diagnostics = append(p.Diagnostics, d)
+}
+
+// reportInferenceGaps 記錄 handler 無法解析、request body 型別不明、any 回應與缺少 token URL 等推斷缺口
+func (p *Parser) reportInferenceGaps() {
+	for _, route := range p.Routes {
+		if len(route.Scopes) > 0 && p.oauth2TokenURL == "" {
+			p.addRouteDiagnostic(route, DiagMissingTokenURL, route.pos, "oauth2 scopes %s are emitted as x-scopes because no token URL is set (use WithOAuth2TokenURL or --oauth2-token-url)", strings.Join(route.Scopes, ", "))
+		}
+
+		handler := route.Handler
+		if handler == nil {
+			p.addRouteDiagnostic(route, DiagUnresolvedHandler, route.pos, "handler %s not found", route.HandlerName)
+			continue
+		}
+		pos := handler.pos
+		if !pos.IsValid() {
+			pos = route.pos
+		}
+
+		if body := handler.RequestBody; body != nil && body.Kind == "struct" && len(body.Fields) == 0 && p.findType(body.Name) == nil {
+			p.addRouteDiagnostic(route, DiagUnknownBindType, pos, "request body type %s of %s has no definition", body.Name, handler.FullName)
+		}
+
+		for _, code := range sortedStatusCodes(handler.Responses) {
+			resp := handler.Responses[code]
+			if isAnyType(resp.Type) {
+				p.addRouteDiagnostic(route, DiagAnyResponse, pos, "%d response of %s is inferred as any", code, handler.FullName)
+			}
+		}
+	}
+}
+
+// isAnyType 判斷回應型別是否只能推斷為 any：函數呼叫的結果，或型別不明、宣告為 any / interface{} 的變數
+func isAnyType(t *TypeInfo) bool {
+	if t == nil {
+		return false
+	}
+	if t.Kind == "any" {
+		return true
+	}
+	return t.Kind == "struct" && len(t.Fields) == 0 && (t.Name == "" || t.Name == "any" || t.Name == "interface{}")
+}
+
+func sortedStatusCodes(responses map[int]*ResponseInfo) []int {
+	codes := make([]int, 0, len(responses))
+	for code := range responses {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	return codes
+}
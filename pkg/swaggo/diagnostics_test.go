@@ -0,0 +1,121 @@
+package swaggo
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestInferenceGapDiagnostics(t *testing.T) {
+	p := analyzeSource(t, `package main
+
+import "github.com/gin-gonic/gin"
+
+func main() {
+	r := gin.Default()
+	prefix := "/dynamic"
+	r.GET(prefix, GetUser)
+	r.GET("/users/:id", GetUser)
+	r.POST("/users", CreateUser)
+	r.GET("/missing", handlers.Missing)
+}
+
+type User struct {
+	Name string `+"`json:\"name\"`"+`
+}
+
+func GetUser(c *gin.Context) {
+	var result interface{}
+	c.JSON(200, User{})
+	c.JSON(500, result)
+}
+
+func CreateUser(c *gin.Context) {
+	var req dto.CreateUserRequest
+	c.ShouldBindJSON(&req)
+	c.JSON(201, User{})
+}
+`)
+
+	byCode := make(map[string][]Diagnostic)
+	for _, d := range p.Diagnostics {
+		byCode[d.Code] = append(byCode[d.Code], d)
+	}
+
+	tests := []struct {
+		code, route, severity string
+		line                  int
+	}{
+		{DiagEmptyRoutePath, "", SeverityWarning, 8},
+		{DiagAnyResponse, "GET /users/:id", SeverityInfo, 18},
+		{DiagUnknownBindType, "POST /users", SeverityWarning, 24},
+		{DiagUnresolvedHandler, "GET /missing", SeverityWarning, 11},
+	}
+	for _, tt := range tests {
+		found := byCode[tt.code]
+		if len(found) != 1 {
+			t.Errorf("%s: got %d diagnostics, want 1: %v", tt.code, len(found), p.Diagnostics)
+			continue
+		}
+		d := found[0]
+		if d.Route != tt.route || d.Severity != tt.severity || d.Position.Line != tt.line {
+			t.Errorf("%s: got route=%q severity=%q line=%d, want %q %q %d", tt.code, d.Route, d.Severity, d.Position.Line, tt.route, tt.severity, tt.line)
+		}
+	}
+}
+
+func TestSkippedRouteDiagnostics(t *testing.T) {
+	p := analyzeSource(t, `package main
+
+import "github.com/gin-gonic/gin"
+
+func Health(c *gin.Context) {}
+
+func main() {
+	r := gin.Default()
+	r.GET("/inline", func(c *gin.Context) {
+		c.JSON(200, "")
+	})
+	r.GET("", Health)
+}
+`)
+
+	byCode := make(map[string][]Diagnostic)
+	for _, d := range p.Diagnostics {
+		byCode[d.Code] = append(byCode[d.Code], d)
+	}
+
+	unresolved := byCode[DiagUnresolvedHandler]
+	if len(unresolved) != 1 || unresolved[0].Position.Line != 9 || !strings.Contains(unresolved[0].Message, "/inline") {
+		t.Errorf("expected one unresolved-handler diagnostic for the inline handler at line 9, got %v", unresolved)
+	}
+	empty := byCode[DiagEmptyRoutePath]
+	if len(empty) != 1 || empty[0].Position.Line != 12 || !strings.Contains(empty[0].Message, "empty string") {
+		t.Errorf("expected one empty-route-path diagnostic for the empty path at line 12, got %v", empty)
+	}
+}
+
+func TestDiagnosticFormat(t *testing.T) {
+	d := Diagnostic{
+		Code:     DiagUnresolvedHandler,
+		Severity: SeverityWarning,
+		Message:  "handler handlers.Missing not found",
+		Route:    "GET /missing",
+	}
+	d.Position.Filename, d.Position.Line, d.Position.Column = "main.go", 11, 2
+
+	want := "main.go:11:2: warning: GET /missing: handler handlers.Missing not found [unresolved-handler]"
+	if got := d.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range []string{`"code":"unresolved-handler"`, `"severity":"warning"`, `"route":"GET /missing"`, `"file":"main.go"`, `"line":11`} {
+		if !strings.Contains(string(data), field) {
+			t.Errorf("JSON %s missing %s", data, field)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package swaggo
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// isEchoRouteMethod 判斷是否為 echo 的路由註冊方法
+func isEchoRouteMethod(name string) bool {
+	switch name {
+	case "GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS", "HEAD", "CONNECT", "TRACE", "Any", "Add", "Match":
+		return true
+	default:
+		return false
+	}
+}
+
+// parseEchoRouteCall 解析 echo 的路由註冊
+// echo 的 handler 緊接在 path 之後，其後才是 middleware：
+//
+//	e.GET(path, handler, ...middleware)
+//	e.Add(method, path, handler, ...middleware)
+//	e.Match([]string{"GET", "POST"}, path, handler, ...middleware)
+func (p *Parser) parseEchoRouteCall(call *ast.CallExpr, method string) []*RouteCall {
+	if !isEchoRouteMethod(method) {
+		return nil
+	}
+
+	switch method {
+	case "Add":
+		if len(call.Args) < 3 {
+			return nil
+		}
+		return []*RouteCall{{
+			Method:      p.extractHTTPMethodArg(call.Args[0]),
+			Path:        p.extractStringArg(call.Args[1]),
+			Handler:     call.Args[2],
+			Middlewares: call.Args[3:],
+		}}
+
+	case "Match":
+		if len(call.Args) < 3 {
+			return nil
+		}
+		methods, ok := call.Args[0].(*ast.CompositeLit)
+		if !ok {
+			return nil
+		}
+		path := p.extractStringArg(call.Args[1])
+		var calls []*RouteCall
+		for _, m := range p.extractStringArgs(methods.Elts) {
+			calls = append(calls, &RouteCall{
+				Method:      strings.ToUpper(m),
+				Path:        path,
+				Handler:     call.Args[2],
+				Middlewares: call.Args[3:],
+			})
+		}
+		return calls
+
+	default:
+		if len(call.Args) < 2 {
+			return nil
+		}
+		return []*RouteCall{{
+			Method:      method,
+			Path:        p.extractStringArg(call.Args[0]),
+			Handler:     call.Args[1],
+			Middlewares: call.Args[2:],
+		}}
+	}
+}
+
+// getEchoParamType 檢查參數是否為 echo 路由相關型別：*echo.Echo, *echo.Group
+func getEchoParamType(expr ast.Expr) string {
+	star, ok := expr.(*ast.StarExpr)
+	if !ok {
+		return ""
+	}
+	sel, ok := star.X.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == "echo" {
+		switch sel.Sel.Name {
+		case "Echo", "Group":
+			return sel.Sel.Name
+		}
+	}
+	return ""
+}
+
+// isEchoHandlerFunc 判斷型別是否為 echo.HandlerFunc
+func isEchoHandlerFunc(expr ast.Expr) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	return ok && ident.Name == "echo" && sel.Sel.Name == "HandlerFunc"
+}
+
+// echoFramework 內建 echo adapter
+type echoFramework struct{ p *Parser }
+
+func (f *echoFramework) Name() string { return FrameworkEcho }
+
+func (f *echoFramework) Match(importPaths []string) bool {
+	return hasImport(importPaths, "github.com/labstack/echo")
+}
+
+func (f *echoFramework) ParseRoute(call *ast.CallExpr) []*RouteCall {
+	return f.p.unwrapWrappedHandlers(f.p.parseEchoRouteCall(call, call.Fun.(*ast.SelectorExpr).Sel.Name))
+}
+
+func (f *echoFramework) ParseGroup(call *ast.CallExpr) *GroupCall { return f.p.parseGroupCall(call) }
+
+func (f *echoFramework) RouterParamType(expr ast.Expr) string { return getEchoParamType(expr) }
+
+func (f *echoFramework) IsHandler(fn *ast.FuncType) bool {
+	if fn.Params == nil {
+		return false
+	}
+	for _, param := range fn.Params.List {
+		if f.p.typeToString(param.Type) == "echo.Context" {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *echoFramework) IsHandlerType(expr ast.Expr) bool { return isEchoHandlerFunc(expr) }
+
+func (f *echoFramework) ContextMethods() map[string]ContextMethod { return nil }
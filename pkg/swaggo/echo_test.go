@@ -0,0 +1,167 @@
+package swaggo
+
+import (
+	"testing"
+)
+
+func TestEchoRoutes(t *testing.T) {
+	src := `package main
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+type CreateUserRequest struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type User struct {
+	ID   int    ` + "`json:\"id\"`" + `
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type UserHandler struct{}
+
+// GetUser 取得使用者
+func (h *UserHandler) GetUser(c echo.Context) error {
+	id := c.Param("id")
+	_ = c.QueryParam("fields")
+	_ = id
+	return c.JSON(http.StatusOK, User{})
+}
+
+func (h *UserHandler) CreateUser(c echo.Context) error {
+	var req CreateUserRequest
+	if err := c.Bind(&req); err != nil {
+		return c.String(http.StatusBadRequest, "bad request")
+	}
+	return c.JSON(http.StatusCreated, User{})
+}
+
+func (h *UserHandler) DeleteUser(c echo.Context) error {
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *UserHandler) Login(c echo.Context) error {
+	_ = c.FormValue("username")
+	return c.NoContent(http.StatusOK)
+}
+
+func main() {
+	e := echo.New()
+	h := &UserHandler{}
+
+	e.POST("/login", h.Login)
+
+	api := e.Group("/api/v1", middleware.JWT([]byte("secret")))
+	api.GET("/users/:id", h.GetUser)
+	api.POST("/users", h.CreateUser, middleware.BodyLimit("1M"))
+	api.Add(http.MethodDelete, "/users/:id", h.DeleteUser)
+	api.Match([]string{"PUT", "PATCH"}, "/users/:id", h.CreateUser)
+}
+`
+	p := analyzeSource(t, src)
+
+	expected := map[string]string{
+		"POST:/login":             "main.UserHandler.Login",
+		"GET:/api/v1/users/:id":   "main.UserHandler.GetUser",
+		"POST:/api/v1/users":      "main.UserHandler.CreateUser",
+		"PUT:/api/v1/users/:id":   "main.UserHandler.CreateUser",
+		"PATCH:/api/v1/users/:id": "main.UserHandler.CreateUser",
+	}
+	for key, handler := range expected {
+		found := false
+		for _, route := range p.Routes {
+			if route.Method+":"+route.Path == key {
+				found = true
+				if route.HandlerName != handler {
+					t.Errorf("route %s: handler = %q, want %q", key, route.HandlerName, handler)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("expected route %s not found", key)
+		}
+	}
+
+	create := findRoute(p, "POST", "/api/v1/users")
+	if create == nil || len(create.Middlewares) != 2 {
+		t.Fatalf("expected group and route middleware on POST /api/v1/users, got %+v", create)
+	}
+	if len(create.Security) != 1 || create.Security[0].BearerFormat != "JWT" {
+		t.Errorf("expected JWT bearer security, got %+v", create.Security)
+	}
+	if create.Handler == nil || create.Handler.RequestBody == nil || create.Handler.RequestBody.Name != "CreateUserRequest" {
+		t.Errorf("expected request body CreateUserRequest, got %+v", create.Handler)
+	}
+
+	get := findRoute(p, "GET", "/api/v1/users/:id")
+	if get == nil || get.Handler == nil {
+		t.Fatal("expected handler for GET /api/v1/users/:id")
+	}
+	var hasQuery bool
+	for _, param := range get.Handler.Parameters {
+		if param.In == "query" && param.Name == "fields" {
+			hasQuery = true
+		}
+	}
+	if !hasQuery {
+		t.Errorf("expected query param fields, got %+v", get.Handler.Parameters)
+	}
+	if resp := get.Handler.Responses[200]; resp == nil || resp.Type == nil || resp.Type.Name != "User" {
+		t.Errorf("expected 200 response of User, got %+v", get.Handler.Responses)
+	}
+
+	gen := New()
+	gen.parser = p
+	spec, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("generate error: %v", err)
+	}
+
+	del := spec.Paths["/api/v1/users/{id}"].Delete
+	if del == nil {
+		t.Fatal("expected DELETE /api/v1/users/{id}")
+	}
+	if resp, ok := del.Responses["204"]; !ok || resp.Content != nil {
+		t.Errorf("expected empty 204 response, got %+v", del.Responses)
+	}
+
+	login := spec.Paths["/login"].Post
+	if login == nil || login.RequestBody == nil {
+		t.Fatal("expected form request body on POST /login")
+	}
+	form, ok := login.RequestBody.Content["application/x-www-form-urlencoded"]
+	if !ok || form.Schema.Properties["username"] == nil {
+		t.Errorf("expected form field username, got %+v", login.RequestBody.Content)
+	}
+}
+
+func TestEchoRegistrar(t *testing.T) {
+	src := `package routes
+
+import "github.com/labstack/echo/v4"
+
+func RegisterOrderRoutes(g *echo.Group) {
+	g.GET("/orders", ListOrders)
+}
+
+func Setup(e *echo.Echo) {
+	api := e.Group("/api")
+	RegisterOrderRoutes(api)
+}
+
+func ListOrders(c echo.Context) error { return nil }
+`
+	p := analyzeSource(t, src)
+
+	if findRoute(p, "GET", "/api/orders") == nil {
+		t.Error("expected route GET /api/orders")
+		for _, route := range p.Routes {
+			t.Logf("  found: %s %s -> %s", route.Method, route.Path, route.HandlerName)
+		}
+	}
+}
@@ -8,14 +8,20 @@ import (
 )
 
 // ParseFromEntry 從指定入口檔案開始，只解析被 import 的 package
+// 可追蹤的 import 包含 go.work 的 use 模組、go.mod 中以 replace 指向本地目錄的模組
+// 有語法錯誤時仍保留其他檔案的結果，並回傳 ParseErrors
 func (p *Parser) ParseFromEntry(entryFile string, projectRoot string) error {
-	// 解析 go.mod 取得 module name
-	moduleName := p.findModuleName(projectRoot)
-	if moduleName == "" {
+	if abs, err := filepath.Abs(entryFile); err == nil {
+		p.entryFile = abs
+	}
+	p.loadWorkspace(projectRoot)
+	if len(p.localModules) == 0 {
 		// 沒有 go.mod，fallback 到掃整個目錄
 		return p.ParseDir(projectRoot)
 	}
 
+	start := len(p.ParseErrors)
+
 	// 收集需要解析的 package 路徑
 	visited := make(map[string]bool)
 	toVisit := []string{}
@@ -36,14 +42,14 @@ func (p *Parser) ParseFromEntry(entryFile string, projectRoot string) error {
 
 		// 解析這個目錄
 		pkgs, err := parser.ParseDir(p.fset, current, func(fi os.FileInfo) bool {
-			return !strings.HasSuffix(fi.Name(), "_test.go")
+			return p.matchBuildFile(current, fi)
 		}, parser.ParseComments)
 		if err != nil {
-			continue
+			p.recordParseError(p.importPathOf(current), err)
 		}
 
-		for name, pkg := range pkgs {
-			p.packages[name] = pkg
+		for _, pkg := range pkgs {
+			p.packages[p.importPathOf(current)] = pkg
 			for _, file := range pkg.Files {
 				p.files = append(p.files, file)
 
@@ -51,39 +57,122 @@ func (p *Parser) ParseFromEntry(entryFile string, projectRoot string) error {
 				for _, imp := range file.Imports {
 					impPath := strings.Trim(imp.Path.Value, `"`)
 
-					// 只追蹤專案內部的 import
-					if strings.HasPrefix(impPath, moduleName) {
-						// 轉換成實際路徑
-						relPath := strings.TrimPrefix(impPath, moduleName)
-						absPath := filepath.Join(projectRoot, relPath)
-
-						if !visited[absPath] && p.dirExists(absPath) && !p.isExcluded(absPath) {
-							toVisit = append(toVisit, absPath)
-						}
+					// 只追蹤 workspace 內的 import，轉換成實際路徑
+					absPath := p.localImportDir(impPath)
+					if absPath != "" && !visited[absPath] && p.dirExists(absPath) && !p.isExcluded(absPath) {
+						toVisit = append(toVisit, absPath)
 					}
 				}
 			}
 		}
 	}
 
-	return nil
+	return p.parseErrorsSince(start)
+}
+
+// loadWorkspace 收集本地模組：go.work 的 use 目錄（沒有 go.work 時為 projectRoot 的 go.mod），
+// 以及這些模組以 replace 指向的本地目錄
+func (p *Parser) loadWorkspace(projectRoot string) {
+	if work := findGoWork(projectRoot); work != nil {
+		for _, dir := range work.uses {
+			if mod := parseGoMod(filepath.Join(dir, "go.mod")); mod != nil {
+				p.addLocalModule(mod.path, mod)
+			}
+		}
+		p.addLocalReplaces(work)
+		return
+	}
+	if mod := parseGoMod(filepath.Join(projectRoot, "go.mod")); mod != nil {
+		p.addLocalModule(mod.path, mod)
+	}
 }
 
-func (p *Parser) findModuleName(projectRoot string) string {
-	goModPath := filepath.Join(projectRoot, "go.mod")
-	data, err := os.ReadFile(goModPath)
+// findGoWork 由 dir 往上尋找 go.work
+func findGoWork(dir string) *goModule {
+	dir, err := filepath.Abs(dir)
 	if err != nil {
+		return nil
+	}
+	for {
+		if work := parseGoMod(filepath.Join(dir, "go.work")); work != nil {
+			return work
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil
+		}
+		dir = parent
+	}
+}
+
+// addLocalModule 以 import path 登記本地模組，並一併加入其 replace 指向的本地模組
+// path 可能與 mod.path 不同，例如 replace github.com/acme/shared => ../shared
+func (p *Parser) addLocalModule(path string, mod *goModule) {
+	if path == "" {
+		return
+	}
+	for _, existing := range p.localModules {
+		if existing.path == path {
+			return
+		}
+	}
+	p.localModules = append(p.localModules, &goModule{
+		dir:      mod.dir,
+		path:     path,
+		requires: mod.requires,
+		replaces: mod.replaces,
+	})
+	p.addLocalReplaces(mod)
+}
+
+func (p *Parser) addLocalReplaces(mod *goModule) {
+	for oldPath, r := range mod.replaces {
+		if r.version != "" {
+			continue
+		}
+		if target := parseGoMod(filepath.Join(r.path, "go.mod")); target != nil {
+			p.addLocalModule(oldPath, target)
+		}
+	}
+}
+
+// localImportDir 以最長的模組路徑比對 import，回傳本地目錄；不屬於本地模組時回傳空字串
+func (p *Parser) localImportDir(importPath string) string {
+	var best *goModule
+	for _, mod := range p.localModules {
+		if importPath != mod.path && !strings.HasPrefix(importPath, mod.path+"/") {
+			continue
+		}
+		if best == nil || len(mod.path) > len(best.path) {
+			best = mod
+		}
+	}
+	if best == nil {
 		return ""
 	}
+	return filepath.Join(best.dir, filepath.FromSlash(strings.TrimPrefix(importPath, best.path)))
+}
 
-	lines := strings.Split(string(data), "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if strings.HasPrefix(line, "module ") {
-			return strings.TrimPrefix(line, "module ")
+// importPathOf 以所屬的本地模組（最內層的 go.mod）推算目錄的 import path，不屬於任何模組時回傳目錄本身
+func (p *Parser) importPathOf(dir string) string {
+	var best *goModule
+	for _, mod := range p.localModules {
+		rel, err := filepath.Rel(mod.dir, dir)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		if best == nil || len(mod.dir) > len(best.dir) {
+			best = mod
 		}
 	}
-	return ""
+	if best == nil {
+		return dir
+	}
+	rel, _ := filepath.Rel(best.dir, dir)
+	if rel == "." {
+		return best.path
+	}
+	return best.path + "/" + filepath.ToSlash(rel)
 }
 
 func (p *Parser) dirExists(path string) bool {
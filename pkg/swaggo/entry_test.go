@@ -0,0 +1,117 @@
+package swaggo
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+const workspaceHandler = `package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/acme/shared/dto"
+)
+
+func Register(r *gin.Engine) {
+	r.GET("/users", ListUsers)
+}
+
+func ListUsers(c *gin.Context) {
+	c.JSON(200, dto.User{})
+}
+`
+
+func TestParseFromEntryWorkspace(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"go.work": `go 1.22
+
+use (
+	./services/api
+	./libs/handlers
+)
+`,
+		"services/api/go.mod": `module github.com/acme/api
+
+go 1.22
+
+require github.com/acme/handlers v0.0.0
+`,
+		"services/api/main.go": `package main
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/acme/handlers"
+)
+
+func main() {
+	r := gin.Default()
+	handlers.Register(r)
+}
+`,
+		"libs/handlers/go.mod": `module github.com/acme/handlers
+
+go 1.22
+
+require github.com/acme/shared v0.0.0
+
+replace github.com/acme/shared => ../shared
+`,
+		"libs/handlers/handlers.go": workspaceHandler,
+		"libs/shared/go.mod":        "module github.com/acme/shared\n",
+		"libs/shared/dto/user.go": `package dto
+
+type User struct {
+	ID int ` + "`json:\"id\"`" + `
+}
+`,
+		"libs/unused/unused.go": "package unused\n\ntype Unused struct{}\n",
+	})
+
+	p := NewParser()
+	apiDir := filepath.Join(root, "services", "api")
+	if err := p.ParseFromEntry(filepath.Join(apiDir, "main.go"), apiDir); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Analyze(); err != nil {
+		t.Fatal(err)
+	}
+
+	if route := findRoute(p, "GET", "/users"); route == nil || route.Handler == nil {
+		t.Fatalf("expected GET /users from workspace module, got %d routes", len(p.Routes))
+	}
+	if typeInfo := p.Types["dto.User"]; typeInfo == nil || len(typeInfo.Fields) != 1 {
+		t.Errorf("expected dto.User from replaced module, got %+v", typeInfo)
+	}
+	if _, ok := p.Types["unused.Unused"]; ok {
+		t.Error("package outside the import graph should not be parsed")
+	}
+}
+
+func TestParseDirNestedModules(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"go.mod":                    "module example.com/root\n",
+		"handlers/handler.go":       "package handlers\n\ntype Root struct{}\n",
+		"tools/go.mod":              "module example.com/tools\n",
+		"tools/handlers/handler.go": "package handlers\n\ntype Tool struct{}\n",
+	})
+
+	p := NewParser()
+	if err := p.ParseDir(root); err != nil {
+		t.Fatal(err)
+	}
+	for _, importPath := range []string{"example.com/root/handlers", "example.com/tools/handlers"} {
+		if _, ok := p.packages[importPath]; !ok {
+			t.Errorf("expected package %s, got %v", importPath, keys(p.packages))
+		}
+	}
+}
+
+func keys[V any](m map[string]V) []string {
+	var result []string
+	for key := range m {
+		result = append(result, key)
+	}
+	return result
+}
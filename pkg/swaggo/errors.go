@@ -0,0 +1,88 @@
+package swaggo
+
+import (
+	"errors"
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"strings"
+)
+
+// DiagParseError 檔案有語法錯誤，該檔案的路由與型別不會出現在文件中
+const DiagParseError = "parse-error"
+
+// ParseError 單一位置的語法錯誤
+type ParseError struct {
+	Package  string // package 的 import path，不屬於任何模組時為目錄
+	Position token.Position
+	Msg      string
+}
+
+func (e *ParseError) Error() string {
+	if e.Position.IsValid() {
+		return fmt.Sprintf("%s: %s: %s", e.Package, e.Position, e.Msg)
+	}
+	return fmt.Sprintf("%s: %s", e.Package, e.Msg)
+}
+
+// ParseErrors 解析過程中的語法錯誤；寬鬆模式下其他檔案的解析結果仍會保留
+type ParseErrors []*ParseError
+
+func (e ParseErrors) Error() string {
+	switch len(e) {
+	case 0:
+		return "no parse errors"
+	case 1:
+		return e[0].Error()
+	}
+	return fmt.Sprintf("%s (and %d more errors)", e[0], len(e)-1)
+}
+
+// IsParseError 判斷 err 是否只包含語法錯誤
+func IsParseError(err error) bool {
+	var perr ParseErrors
+	return errors.As(err, &perr)
+}
+
+// recordParseError 把 go/parser 的錯誤轉成 ParseError，並記錄為 diagnostic
+func (p *Parser) recordParseError(pkgPath string, err error) {
+	var list scanner.ErrorList
+	if !errors.As(err, &list) {
+		p.addParseError(&ParseError{Package: pkgPath, Msg: err.Error()})
+		return
+	}
+	for _, e := range list {
+		p.addParseError(&ParseError{Package: pkgPath, Position: e.Pos, Msg: e.Msg})
+	}
+}
+
+func (p *Parser) addParseError(e *ParseError) {
+	p.ParseErrors = append(p.ParseErrors, e)
+	p.appendDiagnostic(Diagnostic{
+		Code:     DiagParseError,
+		Severity: diagnosticSeverity[DiagParseError],
+		Message:  fmt.Sprintf("%s: %s", e.Package, e.Msg),
+		Position: e.Position,
+	})
+}
+
+// parseErrorsSince 回傳 start 之後新增的語法錯誤，沒有時回傳 nil
+func (p *Parser) parseErrorsSince(start int) error {
+	if len(p.ParseErrors) == start {
+		return nil
+	}
+	return append(ParseErrors{}, p.ParseErrors[start:]...)
+}
+
+// StrictError strict 模式下導致產生失敗的問題
+type StrictError struct {
+	Diagnostics []Diagnostic
+}
+
+func (e *StrictError) Error() string {
+	lines := make([]string, 0, len(e.Diagnostics))
+	for _, d := range e.Diagnostics {
+		lines = append(lines, "  "+d.String())
+	}
+	return fmt.Sprintf("strict mode: %d problems\n%s", len(e.Diagnostics), strings.Join(lines, "\n"))
+}
@@ -15,73 +15,79 @@ func (p *Parser) extractTypes(file *ast.File) {
 			return true
 		}
 
-		st, ok := ts.Type.(*ast.StructType)
-		if !ok {
-			return true
+		if typeInfo := p.extractTypeSpec(ts, pkgName); typeInfo != nil {
+			p.Types[typeInfo.FullName] = typeInfo
+			p.Types[ts.Name.Name] = typeInfo
 		}
 
-		fullName := pkgName + "." + ts.Name.Name
-		typeInfo := &TypeInfo{
-			Name:     ts.Name.Name,
-			FullName: fullName,
-			Package:  pkgName,
-			Kind:     "struct",
-		}
+		return true
+	})
+}
 
-		if ts.Doc != nil {
-			typeInfo.Comment = strings.TrimSpace(ts.Doc.Text())
-		}
+// extractTypeSpec 由 struct 型別宣告建立 TypeInfo，非 struct 回傳 nil
+func (p *Parser) extractTypeSpec(ts *ast.TypeSpec, pkgName string) *TypeInfo {
+	st, ok := ts.Type.(*ast.StructType)
+	if !ok {
+		return nil
+	}
 
-		for _, field := range st.Fields.List {
-			if len(field.Names) == 0 {
-				continue
-			}
+	typeInfo := &TypeInfo{
+		Name:     ts.Name.Name,
+		FullName: pkgName + "." + ts.Name.Name,
+		Package:  pkgName,
+		Kind:     "struct",
+	}
 
-			fi := &FieldInfo{
-				Name: field.Names[0].Name,
-				Type: p.typeToString(field.Type),
-				Tags: make(map[string]string),
-			}
+	if ts.Doc != nil {
+		typeInfo.Comment = strings.TrimSpace(ts.Doc.Text())
+	}
 
-			if field.Tag != nil {
-				tag := strings.Trim(field.Tag.Value, "`")
-				fi.Tags = parseStructTags(tag)
+	for _, field := range st.Fields.List {
+		if len(field.Names) == 0 {
+			continue
+		}
 
-				if jsonTag, ok := fi.Tags["json"]; ok {
-					parts := strings.Split(jsonTag, ",")
-					if parts[0] != "-" {
-						fi.JSONName = parts[0]
-					}
-				}
-				if fi.JSONName == "" {
-					fi.JSONName = fi.Name
-				}
+		fi := &FieldInfo{
+			Name: field.Names[0].Name,
+			Type: p.typeToString(field.Type),
+			Tags: make(map[string]string),
+		}
 
-				if bindTag, ok := fi.Tags["binding"]; ok {
-					fi.Required = strings.Contains(bindTag, "required")
-				}
+		if field.Tag != nil {
+			tag := strings.Trim(field.Tag.Value, "`")
+			fi.Tags = parseStructTags(tag)
 
-				if example, ok := fi.Tags["example"]; ok {
-					fi.Example = example
+			if jsonTag, ok := fi.Tags["json"]; ok {
+				parts := strings.Split(jsonTag, ",")
+				if parts[0] != "-" {
+					fi.JSONName = parts[0]
 				}
-			} else {
+			}
+			if fi.JSONName == "" {
 				fi.JSONName = fi.Name
 			}
 
-			if field.Comment != nil {
-				fi.Comment = strings.TrimSpace(field.Comment.Text())
-			} else if field.Doc != nil {
-				fi.Comment = strings.TrimSpace(field.Doc.Text())
+			if bindTag, ok := fi.Tags["binding"]; ok {
+				fi.Required = strings.Contains(bindTag, "required")
 			}
 
-			typeInfo.Fields = append(typeInfo.Fields, fi)
+			if example, ok := fi.Tags["example"]; ok {
+				fi.Example = example
+			}
+		} else {
+			fi.JSONName = fi.Name
 		}
 
-		p.Types[fullName] = typeInfo
-		p.Types[ts.Name.Name] = typeInfo
+		if field.Comment != nil {
+			fi.Comment = strings.TrimSpace(field.Comment.Text())
+		} else if field.Doc != nil {
+			fi.Comment = strings.TrimSpace(field.Doc.Text())
+		}
 
-		return true
-	})
+		typeInfo.Fields = append(typeInfo.Fields, fi)
+	}
+
+	return typeInfo
 }
 
 func (p *Parser) extractHandlers(file *ast.File) {
@@ -93,7 +99,7 @@ func (p *Parser) extractHandlers(file *ast.File) {
 			return true
 		}
 
-		if !p.isGinHandler(fn) {
+		if !p.isHandlerDecl(fn) {
 			return true
 		}
 
@@ -102,6 +108,7 @@ func (p *Parser) extractHandlers(file *ast.File) {
 			Package:   pkgName,
 			FilePath:  p.fset.Position(fn.Pos()).Filename,
 			Responses: make(map[int]*ResponseInfo),
+			pos:       fn.Pos(),
 		}
 
 		if fn.Recv != nil && len(fn.Recv.List) > 0 {
@@ -129,88 +136,19 @@ func (p *Parser) extractHandlers(file *ast.File) {
 	})
 }
 
-func (p *Parser) extractControllerInstances(file *ast.File) {
-	pkgName := file.Name.Name
-
-	ast.Inspect(file, func(n ast.Node) bool {
-		switch node := n.(type) {
-		case *ast.GenDecl:
-			for _, spec := range node.Specs {
-				if vs, ok := spec.(*ast.ValueSpec); ok {
-					for i, name := range vs.Names {
-						if i < len(vs.Values) {
-							typeName := p.inferControllerType(vs.Values[i], pkgName)
-							if typeName != "" {
-								p.controllerInstances[name.Name] = typeName
-							}
-						}
-					}
-				}
-			}
-
-		case *ast.AssignStmt:
-			for i, lhs := range node.Lhs {
-				if ident, ok := lhs.(*ast.Ident); ok {
-					if i < len(node.Rhs) {
-						typeName := p.inferControllerType(node.Rhs[i], pkgName)
-						if typeName != "" {
-							p.controllerInstances[ident.Name] = typeName
-						}
-					}
-				}
-			}
-		}
-
-		return true
-	})
-}
-
 func (p *Parser) extractRoutes(file *ast.File) {
 	pkgName := file.Name.Name
-	groupPrefixes := make(map[string]string)
-
-	// 收集這個檔案中的 registrar 函數位置，用於跳過
-	registrarRanges := make(map[token.Pos]token.Pos) // start -> end
-	for _, reg := range p.routeRegistrars {
-		if reg.File == file && reg.FuncDecl != nil && reg.FuncDecl.Body != nil {
-			registrarRanges[reg.FuncDecl.Body.Pos()] = reg.FuncDecl.Body.End()
-		}
-	}
-
-	// 檢查節點是否在 registrar 函數內
-	isInRegistrar := func(pos token.Pos) bool {
-		for start, end := range registrarRanges {
-			if pos >= start && pos <= end {
-				return true
-			}
-		}
-		return false
-	}
+	framework := p.fileFramework(file)
+	groupPrefixes := p.collectMountPrefixes(file)
+	groupMiddlewares := make(map[string][]*MiddlewareInfo)
 
 	p.extractDynamicRoutes(file, pkgName)
 	p.extractForRangeRoutes(file, pkgName)
 
-	ast.Inspect(file, func(n ast.Node) bool {
+	p.inspectRouterScopes(file, pkgName, framework, groupPrefixes, groupMiddlewares, func(n ast.Node, groupPrefixes map[string]string, groupMiddlewares map[string][]*MiddlewareInfo) bool {
 		if assign, ok := n.(*ast.AssignStmt); ok {
-			for i, rhs := range assign.Rhs {
-				if call, ok := rhs.(*ast.CallExpr); ok {
-					if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
-						if sel.Sel.Name == "Group" && len(call.Args) > 0 {
-							if prefix := p.extractStringArg(call.Args[0]); prefix != "" {
-								parentPrefix := ""
-								if parentIdent, ok := sel.X.(*ast.Ident); ok {
-									parentPrefix = groupPrefixes[parentIdent.Name]
-								}
-								if i < len(assign.Lhs) {
-									if ident, ok := assign.Lhs[i].(*ast.Ident); ok {
-										groupPrefixes[ident.Name] = parentPrefix + prefix
-									}
-								}
-							}
-						}
-					}
-				}
-			}
+			p.updateGroupMiddlewares(assign, pkgName, groupMiddlewares)
+			p.updateGroupPrefixes(assign, groupPrefixes)
 			return true
 		}
 
@@ -224,70 +162,63 @@ func (p *Parser) extractRoutes(file *ast.File) {
 			return true
 		}
 
-		method := sel.Sel.Name
-
-		httpMethods := map[string]bool{
-			"GET": true, "POST": true, "PUT": true, "DELETE": true,
-			"PATCH": true, "OPTIONS": true, "HEAD": true,
-			"Any": true, "Handle": true,
-		}
-
-		if !httpMethods[method] {
-			return true
-		}
-
 		// 跳過 registrar 函數內的路由（會由 extractRoutesWithPrefix 處理）
-		if isInRegistrar(call.Pos()) {
+		if p.inRegistrarBody(file, call.Pos()) {
 			return true
 		}
 
-		// Handle() 的簽名是 Handle(method, path, ...handlers)，需要至少 3 個引數
-		// 其他 HTTP method 的簽名是 GET(path, ...handlers)，需要至少 2 個引數
-		if method == "Handle" {
-			if len(call.Args) < 3 {
-				return true
-			}
-		} else {
-			if len(call.Args) < 2 {
-				return true
-			}
-		}
-
-		var httpMethod, path string
-		if method == "Handle" {
-			httpMethod = p.extractStringArg(call.Args[0])
-			path = p.extractStringArg(call.Args[1])
-		} else {
-			httpMethod = method
-			path = p.extractStringArg(call.Args[0])
-		}
+		p.collectUseCall(call, pkgName, groupMiddlewares)
+		p.tryAddThirdPartyRoutes(call, groupPrefixes, groupMiddlewares)
 
-		groupPrefix := ""
-		if receiverIdent, ok := sel.X.(*ast.Ident); ok {
-			groupPrefix = groupPrefixes[receiverIdent.Name]
-		}
+		groupPrefix := p.getReceiverPrefix(sel.X, groupPrefixes)
 
-		if path == "" && groupPrefix == "" {
-			return true
-		}
+		for _, rc := range p.parseRouteCall(call, framework) {
+			if rc.Path == "" && groupPrefix == "" {
+				p.addDiagnostic(DiagEmptyRoutePath, call.Pos(), "%s route path %s, route skipped", rc.Method, routePathProblem(call))
+				continue
+			}
+			if rc.Method == "" {
+				p.addDiagnostic(DiagUnresolvedMethod, call.Pos(), "route %s%s method is not a string literal or Method constant, route skipped", groupPrefix, rc.Path)
+				continue
+			}
 
-		handlerArg := call.Args[len(call.Args)-1]
-		handlerName := p.resolveHandlerName(handlerArg, pkgName)
+			handlerName := p.resolveHandlerName(rc.Handler, pkgName)
+			if handlerName == "" {
+				p.addDiagnostic(DiagUnresolvedHandler, call.Pos(), "%s %s%s handler is not a named function or method, route skipped", rc.Method, groupPrefix, rc.Path)
+				continue
+			}
+			if shouldSkipHandler(handlerName) {
+				continue
+			}
 
-		if handlerName == "" || shouldSkipHandler(handlerName) {
-			return true
+			middlewares := p.routeMiddlewares(rc, pkgName, p.resolveGroupMiddlewares(sel.X, groupMiddlewares))
+			route := p.newRouteInfo(rc, groupPrefix, handlerName, middlewares)
+			route.Registered = p.registeredFunc(rc, pkgName)
+			route.pos = call.Pos()
+			p.Routes = append(p.Routes, route)
 		}
+		return true
+	})
+}
 
-		route := &RouteInfo{
-			Method:      httpMethod,
-			Path:        groupPrefix + path,
-			HandlerName: handlerName,
-			Group:       groupPrefix,
+// routePathProblem 說明路由 path 無法使用的原因：空字串字面量，或不是字串字面量
+func routePathProblem(call *ast.CallExpr) string {
+	empty := false
+	ast.Inspect(call, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.BasicLit:
+			if n.Kind == token.STRING && (n.Value == `""` || n.Value == "``") {
+				empty = true
+			}
 		}
-
-		p.Routes = append(p.Routes, route)
-		return true
+		return !empty
 	})
+	if empty {
+		return "is an empty string"
+	}
+	return "is not a string literal"
 }
 
 func (p *Parser) extractDynamicRoutes(file *ast.File, pkgName string) {
@@ -305,6 +236,7 @@ func (p *Parser) extractDynamicRoutes(file *ast.File, pkgName string) {
 		for _, elt := range cl.Elts {
 			route := p.parseRouteDefinition(elt, pkgName)
 			if route != nil {
+				route.pos = elt.Pos()
 				p.Routes = append(p.Routes, route)
 			}
 		}
@@ -322,6 +254,7 @@ func (p *Parser) extractForRangeRoutes(file *ast.File, pkgName string) {
 	}
 
 	groupPrefixes := p.collectGroupPrefixes(file)
+	groupMiddlewares := p.collectGroupMiddlewares(file)
 
 	ast.Inspect(file, func(n ast.Node) bool {
 		rangeStmt, ok := n.(*ast.RangeStmt)
@@ -354,7 +287,7 @@ func (p *Parser) extractForRangeRoutes(file *ast.File, pkgName string) {
 			return true
 		}
 
-		p.processForRangeBody(rangeStmt.Body, valueVar, elements, pkgName, groupPrefixes)
+		p.processForRangeBody(rangeStmt.Body, valueVar, elements, pkgName, groupPrefixes, groupMiddlewares)
 		return true
 	})
 }
@@ -407,7 +340,7 @@ func (p *Parser) collectSliceLiterals(file *ast.File) map[string][]ast.Expr {
 }
 
 // processForRangeBody 處理 for-range body 中的路由呼叫
-func (p *Parser) processForRangeBody(body *ast.BlockStmt, valueVar string, elements []ast.Expr, pkgName string, groupPrefixes map[string]string) {
+func (p *Parser) processForRangeBody(body *ast.BlockStmt, valueVar string, elements []ast.Expr, pkgName string, groupPrefixes map[string]string, groupMiddlewares map[string][]*MiddlewareInfo) {
 	if body == nil {
 		return
 	}
@@ -441,6 +374,8 @@ func (p *Parser) processForRangeBody(body *ast.BlockStmt, valueVar string, eleme
 
 			route := p.resolveForRangeRouteCall(call, method, valueVar, fieldValues, pkgName, groupPrefix)
 			if route != nil && !p.routeExists(route.Method, route.Path) {
+				route.Middlewares = p.resolveGroupMiddlewares(sel.X, groupMiddlewares)
+				route.pos = call.Pos()
 				p.Routes = append(p.Routes, route)
 			}
 		}
@@ -573,7 +508,7 @@ func (p *Parser) analyzeHandlerBody(fn *ast.FuncDecl, handler *HandlerInfo) {
 		}
 	}
 
-	ast.Inspect(fn.Body, func(n ast.Node) bool {
+	p.inspectHandlerBody(fn.Body, handler, func(n ast.Node) bool {
 		call, ok := n.(*ast.CallExpr)
 		if !ok {
 			return true
@@ -586,8 +521,19 @@ func (p *Parser) analyzeHandlerBody(fn *ast.FuncDecl, handler *HandlerInfo) {
 
 		method := sel.Sel.Name
 
+		// 自訂框架宣告的 context 方法優先
+		if cm, ok := p.contextMethod(call, sel.Sel.Name); ok {
+			p.applyContextMethod(call, cm, handler, localVarTypes)
+			return true
+		}
+
+		if resp := p.fiberResponse(call, sel, localVarTypes); resp != nil {
+			handler.Responses[resp.StatusCode] = resp
+			return true
+		}
+
 		switch method {
-		case "Param":
+		case "Param", "Params", "PathValue":
 			if len(call.Args) > 0 {
 				if name := p.extractStringArg(call.Args[0]); name != "" {
 					handler.Parameters = append(handler.Parameters, &ParameterInfo{
@@ -599,7 +545,7 @@ func (p *Parser) analyzeHandlerBody(fn *ast.FuncDecl, handler *HandlerInfo) {
 				}
 			}
 
-		case "Query":
+		case "Query", "QueryParam":
 			if len(call.Args) > 0 {
 				if name := p.extractStringArg(call.Args[0]); name != "" {
 					handler.Parameters = append(handler.Parameters, &ParameterInfo{
@@ -610,6 +556,30 @@ func (p *Parser) analyzeHandlerBody(fn *ast.FuncDecl, handler *HandlerInfo) {
 				}
 			}
 
+		case "FormValue":
+			if len(call.Args) > 0 {
+				if name := p.extractStringArg(call.Args[0]); name != "" {
+					handler.Parameters = append(handler.Parameters, &ParameterInfo{
+						Name: name,
+						Type: "string",
+						In:   "formData",
+					})
+				}
+			}
+
+		case "URLParam":
+			// chi.URLParam(r, "id")
+			if len(call.Args) >= 2 {
+				if name := p.extractStringArg(call.Args[1]); name != "" {
+					handler.Parameters = append(handler.Parameters, &ParameterInfo{
+						Name:     name,
+						Type:     "string",
+						In:       "path",
+						Required: true,
+					})
+				}
+			}
+
 		case "DefaultQuery":
 			if len(call.Args) >= 2 {
 				if name := p.extractStringArg(call.Args[0]); name != "" {
@@ -633,7 +603,29 @@ func (p *Parser) analyzeHandlerBody(fn *ast.FuncDecl, handler *HandlerInfo) {
 				}
 			}
 
-		case "ShouldBindQuery", "BindQuery":
+		case "Get":
+			// c.Request().Header.Get("X") / c.Request.Header.Get("X")
+			if isHeaderGet(sel) && len(call.Args) > 0 {
+				if name := p.extractStringArg(call.Args[0]); name != "" {
+					handler.Parameters = append(handler.Parameters, &ParameterInfo{
+						Name: name,
+						Type: "string",
+						In:   "header",
+					})
+				}
+			}
+			// r.URL.Query().Get("q")
+			if isURLQueryGet(sel) && len(call.Args) > 0 {
+				if name := p.extractStringArg(call.Args[0]); name != "" {
+					handler.Parameters = append(handler.Parameters, &ParameterInfo{
+						Name: name,
+						Type: inferQueryParamType(name),
+						In:   "query",
+					})
+				}
+			}
+
+		case "ShouldBindQuery", "BindQuery", "QueryParser":
 			if len(call.Args) > 0 {
 				typeName := p.extractTypeFromBindArgWithLocals(call.Args[0], localVarTypes)
 				if typeName != "" {
@@ -681,7 +673,14 @@ func (p *Parser) analyzeHandlerBody(fn *ast.FuncDecl, handler *HandlerInfo) {
 				}
 			}
 
-		case "ShouldBindJSON", "BindJSON", "ShouldBind", "Bind":
+		case "Decode":
+			// json.NewDecoder(r.Body).Decode(&req)
+			if !isJSONDecoderCall(sel.X) {
+				return true
+			}
+			fallthrough
+
+		case "ShouldBindJSON", "BindJSON", "ShouldBind", "Bind", "BodyParser":
 			if len(call.Args) > 0 {
 				typeName := p.extractTypeFromBindArgWithLocals(call.Args[0], localVarTypes)
 				if typeName != "" {
@@ -713,23 +712,21 @@ func (p *Parser) analyzeHandlerBody(fn *ast.FuncDecl, handler *HandlerInfo) {
 					}
 				}
 			}
+
+		case "NoContent":
+			if len(call.Args) >= 1 {
+				statusCode := p.extractStatusCode(call.Args[0])
+				if statusCode > 0 {
+					handler.Responses[statusCode] = &ResponseInfo{StatusCode: statusCode}
+				}
+			}
 		}
 
 		return true
 	})
-}
 
-func (p *Parser) isGinHandler(fn *ast.FuncDecl) bool {
-	if fn.Type.Params == nil || len(fn.Type.Params.List) == 0 {
-		return false
-	}
-	for _, param := range fn.Type.Params.List {
-		typeStr := p.typeToString(param.Type)
-		if strings.Contains(typeStr, "gin.Context") || strings.Contains(typeStr, "Context") {
-			return true
-		}
-	}
-	return false
+	p.analyzeNetHTTPResponses(fn.Body, handler, localVarTypes)
+	p.collectMuxVars(fn.Body, handler)
 }
 
 func (p *Parser) extractReceiverType(expr ast.Expr) string {
@@ -742,35 +739,6 @@ func (p *Parser) extractReceiverType(expr ast.Expr) string {
 	return ""
 }
 
-func (p *Parser) inferControllerType(expr ast.Expr, currentPkg string) string {
-	switch e := expr.(type) {
-	case *ast.UnaryExpr:
-		if e.Op == token.AND {
-			return p.inferControllerType(e.X, currentPkg)
-		}
-	case *ast.CompositeLit:
-		return p.typeExprToFullName(e.Type, currentPkg)
-	case *ast.CallExpr:
-		if sel, ok := e.Fun.(*ast.SelectorExpr); ok {
-			funcName := sel.Sel.Name
-			if strings.HasPrefix(funcName, "New") {
-				typeName := strings.TrimPrefix(funcName, "New")
-				if pkgIdent, ok := sel.X.(*ast.Ident); ok {
-					return pkgIdent.Name + "." + typeName
-				}
-			}
-		}
-		if ident, ok := e.Fun.(*ast.Ident); ok {
-			funcName := ident.Name
-			if strings.HasPrefix(funcName, "New") {
-				typeName := strings.TrimPrefix(funcName, "New")
-				return currentPkg + "." + typeName
-			}
-		}
-	}
-	return ""
-}
-
 func (p *Parser) typeExprToFullName(expr ast.Expr, currentPkg string) string {
 	switch t := expr.(type) {
 	case *ast.Ident:
@@ -794,14 +762,29 @@ func (p *Parser) resolveHandlerName(expr ast.Expr, currentPkg string) string {
 			varName := pkgOrVar.Name
 			methodName := h.Sel.Name
 
-			if ctrlType, ok := p.controllerInstances[varName]; ok {
+			if ctrlType, ok := p.instanceType(pkgOrVar); ok {
 				return ctrlType + "." + methodName
 			}
 			return varName + "." + methodName
 		}
 	case *ast.CallExpr:
+		// 泛型 adapter: Typed(h.GetUser) 以被包裝的函數為 handler
+		if target := p.typedAdapterTarget(h, currentPkg); target != "" {
+			return target
+		}
+		// 可特化的工廠呼叫: MakeCRUDList[User]() 或 MakeHandler(&User{})
+		if spec := p.specializeFactoryCall(h, currentPkg); spec != nil {
+			return spec.name
+		}
 		// 工廠函數呼叫: MakeHandler() 或 pkg.MakeHandler()
-		switch fn := h.Fun.(type) {
+		fun := h.Fun
+		switch f := fun.(type) {
+		case *ast.IndexExpr:
+			fun = f.X
+		case *ast.IndexListExpr:
+			fun = f.X
+		}
+		switch fn := fun.(type) {
 		case *ast.Ident:
 			// 同 package 呼叫: MakeGreetHandler("Hello")
 			return currentPkg + "." + fn.Name
@@ -0,0 +1,160 @@
+package swaggo
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// parseFiberRouteCall 解析 fiber 的路由註冊，與 gin 相同，最後一個引數是 handler
+//
+//	app.Get(path, ...handlers)
+//	app.Add(method, path, ...handlers)
+func (p *Parser) parseFiberRouteCall(call *ast.CallExpr, method string) []*RouteCall {
+	start := 1
+	rc := &RouteCall{}
+
+	switch method {
+	case "Get", "Post", "Put", "Delete", "Patch", "Head", "Options", "Connect", "Trace", "All":
+		if len(call.Args) < 2 {
+			return nil
+		}
+		rc.Method = strings.ToUpper(method)
+		if method == "All" {
+			rc.Method = "Any"
+		}
+		rc.Path = p.extractStringArg(call.Args[0])
+	case "Add":
+		if len(call.Args) < 3 {
+			return nil
+		}
+		rc.Method = p.extractHTTPMethodArg(call.Args[0])
+		rc.Path = p.extractStringArg(call.Args[1])
+		start = 2
+	default:
+		return nil
+	}
+
+	rc.Handler = call.Args[len(call.Args)-1]
+	rc.Middlewares = call.Args[start : len(call.Args)-1]
+	return []*RouteCall{rc}
+}
+
+// fiberResponse 解析 fiber 的回應寫法，status code 來自 c.Status(x) 鏈，沒有則為 200
+//
+//	c.JSON(user) / c.Status(fiber.StatusCreated).JSON(user)
+//	c.SendString("ok") / c.SendStatus(fiber.StatusNoContent)
+//	fiber.NewError(fiber.StatusNotFound, "not found")
+func (p *Parser) fiberResponse(call *ast.CallExpr, sel *ast.SelectorExpr, localVarTypes map[string]string) *ResponseInfo {
+	switch sel.Sel.Name {
+	case "JSON":
+		// gin/echo 的 c.JSON(code, obj) 沒有 Status 鏈且有兩個引數
+		code, chained := p.chainedStatusCode(sel.X)
+		if len(call.Args) == 0 || (!chained && len(call.Args) != 1) {
+			return nil
+		}
+		resp := &ResponseInfo{StatusCode: code}
+		resp.Type, resp.IsArray = p.extractResponseTypeWithLocals(call.Args[0], localVarTypes)
+		return resp
+
+	case "SendString":
+		if len(call.Args) != 1 {
+			return nil
+		}
+		code, _ := p.chainedStatusCode(sel.X)
+		return &ResponseInfo{StatusCode: code, Type: &TypeInfo{Kind: "primitive", Name: "string"}}
+
+	case "SendStatus":
+		if len(call.Args) != 1 {
+			return nil
+		}
+		if code := p.extractStatusCode(call.Args[0]); code > 0 {
+			return &ResponseInfo{StatusCode: code}
+		}
+
+	case "NewError":
+		if ident, ok := sel.X.(*ast.Ident); !ok || ident.Name != "fiber" || len(call.Args) == 0 {
+			return nil
+		}
+		if code := p.extractStatusCode(call.Args[0]); code > 0 {
+			return &ResponseInfo{StatusCode: code, Type: &TypeInfo{Kind: "primitive", Name: "string"}}
+		}
+	}
+	return nil
+}
+
+// chainedStatusCode 取得 c.Status(x).JSON(...) 中 Status 設定的 code
+func (p *Parser) chainedStatusCode(expr ast.Expr) (int, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return 200, false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Status" {
+		return 200, false
+	}
+	if code := p.extractStatusCode(call.Args[0]); code > 0 {
+		return code, true
+	}
+	return 200, false
+}
+
+// getFiberParamType 檢查參數是否為 fiber 路由相關型別：*fiber.App, *fiber.Group, fiber.Router
+func getFiberParamType(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == "fiber" {
+		switch sel.Sel.Name {
+		case "App", "Group", "Router":
+			return sel.Sel.Name
+		}
+	}
+	return ""
+}
+
+// isFiberHandlerFunc 判斷型別是否為 fiber.Handler
+func isFiberHandlerFunc(expr ast.Expr) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	return ok && ident.Name == "fiber" && sel.Sel.Name == "Handler"
+}
+
+// fiberFramework 內建 fiber adapter
+type fiberFramework struct{ p *Parser }
+
+func (f *fiberFramework) Name() string { return FrameworkFiber }
+
+func (f *fiberFramework) Match(importPaths []string) bool {
+	return hasImport(importPaths, "github.com/gofiber/fiber")
+}
+
+func (f *fiberFramework) ParseRoute(call *ast.CallExpr) []*RouteCall {
+	return f.p.parseFiberRouteCall(call, call.Fun.(*ast.SelectorExpr).Sel.Name)
+}
+
+func (f *fiberFramework) ParseGroup(call *ast.CallExpr) *GroupCall { return f.p.parseGroupCall(call) }
+
+func (f *fiberFramework) RouterParamType(expr ast.Expr) string { return getFiberParamType(expr) }
+
+func (f *fiberFramework) IsHandler(fn *ast.FuncType) bool {
+	if fn.Params == nil {
+		return false
+	}
+	for _, param := range fn.Params.List {
+		if strings.Contains(f.p.typeToString(param.Type), "fiber.Ctx") {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fiberFramework) IsHandlerType(expr ast.Expr) bool { return isFiberHandlerFunc(expr) }
+
+func (f *fiberFramework) ContextMethods() map[string]ContextMethod { return nil }
@@ -0,0 +1,164 @@
+package swaggo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFiberRoutes(t *testing.T) {
+	src := `package main
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/basicauth"
+)
+
+type CreateUserRequest struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type User struct {
+	ID   int    ` + "`json:\"id\"`" + `
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type UserHandler struct{}
+
+// GetUser 取得使用者
+func (h *UserHandler) GetUser(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return fiber.NewError(fiber.StatusNotFound, "user not found")
+	}
+	_ = c.Query("fields")
+	return c.JSON(User{})
+}
+
+func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
+	var req CreateUserRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).SendString("bad request")
+	}
+	return c.Status(fiber.StatusCreated).JSON(User{})
+}
+
+func (h *UserHandler) DeleteUser(c *fiber.Ctx) error {
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func logger() fiber.Handler {
+	return func(c *fiber.Ctx) error { return c.Next() }
+}
+
+func main() {
+	app := fiber.New()
+	h := &UserHandler{}
+
+	api := app.Group("/api/v1", logger())
+	api.Get("/users/:id", h.GetUser)
+	api.Post("/users", h.CreateUser)
+	api.Delete("/users/:id", basicauth.New(basicauth.Config{}), h.DeleteUser)
+
+	app.Listen(":3000")
+}
+`
+	p := analyzeSource(t, src)
+
+	expected := map[string]string{
+		"GET:/api/v1/users/:id":    "main.UserHandler.GetUser",
+		"POST:/api/v1/users":       "main.UserHandler.CreateUser",
+		"DELETE:/api/v1/users/:id": "main.UserHandler.DeleteUser",
+	}
+	if len(p.Routes) != len(expected) {
+		for _, route := range p.Routes {
+			t.Logf("  found: %s %s -> %s", route.Method, route.Path, route.HandlerName)
+		}
+		t.Fatalf("expected %d routes, got %d", len(expected), len(p.Routes))
+	}
+	for key, handler := range expected {
+		found := false
+		for _, route := range p.Routes {
+			if route.Method+":"+route.Path == key {
+				found = true
+				if route.HandlerName != handler {
+					t.Errorf("route %s: handler = %q, want %q", key, route.HandlerName, handler)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("expected route %s not found", key)
+		}
+	}
+
+	get := findRoute(p, "GET", "/api/v1/users/:id")
+	if get.Handler == nil {
+		t.Fatal("expected handler for GET /api/v1/users/:id")
+	}
+	if resp := get.Handler.Responses[200]; resp == nil || resp.Type == nil || resp.Type.Name != "User" {
+		t.Errorf("expected 200 response of User, got %+v", get.Handler.Responses)
+	}
+	if _, ok := get.Handler.Responses[404]; !ok {
+		t.Errorf("expected 404 response from fiber.NewError, got %+v", get.Handler.Responses)
+	}
+
+	create := findRoute(p, "POST", "/api/v1/users")
+	if create.Handler == nil || create.Handler.RequestBody == nil || create.Handler.RequestBody.Name != "CreateUserRequest" {
+		t.Fatalf("expected request body CreateUserRequest, got %+v", create.Handler)
+	}
+	if resp := create.Handler.Responses[201]; resp == nil || resp.Type == nil || resp.Type.Name != "User" {
+		t.Errorf("expected 201 response of User, got %+v", create.Handler.Responses)
+	}
+	if resp := create.Handler.Responses[400]; resp == nil || resp.Type == nil || resp.Type.Name != "string" {
+		t.Errorf("expected 400 string response, got %+v", create.Handler.Responses)
+	}
+	if _, ok := create.Handler.Responses[200]; ok {
+		t.Error("chained Status() should replace the default 200")
+	}
+
+	del := findRoute(p, "DELETE", "/api/v1/users/:id")
+	if len(del.Middlewares) != 2 {
+		t.Errorf("expected group and route middleware, got %+v", del.Middlewares)
+	}
+	if len(del.Security) != 1 || del.Security[0].Scheme != "basic" {
+		t.Errorf("expected basic auth from basicauth.New, got %+v", del.Security)
+	}
+	if resp := del.Handler.Responses[204]; resp == nil || resp.Type != nil {
+		t.Errorf("expected empty 204 response, got %+v", del.Handler.Responses)
+	}
+}
+
+func TestFiberAddMethodConstant(t *testing.T) {
+	p := analyzeSource(t, `package main
+
+import "github.com/gofiber/fiber/v2"
+
+func Health(c *fiber.Ctx) error { return c.SendString("ok") }
+
+func main() {
+	app := fiber.New()
+	method := loadMethod()
+	app.Add(fiber.MethodGet, "/health", Health)
+	app.Add(method, "/dynamic", Health)
+}
+`)
+
+	if route := findRoute(p, "GET", "/health"); route == nil {
+		for _, route := range p.Routes {
+			t.Logf("  found: %q %s -> %s", route.Method, route.Path, route.HandlerName)
+		}
+		t.Fatal("expected GET /health from fiber.MethodGet")
+	}
+	if len(p.Routes) != 1 {
+		t.Errorf("route with unresolved method should be skipped, got %d routes", len(p.Routes))
+	}
+
+	var diag *Diagnostic
+	for i, d := range p.Diagnostics {
+		if d.Code == DiagUnresolvedMethod {
+			diag = &p.Diagnostics[i]
+		}
+	}
+	if diag == nil || !strings.Contains(diag.Message, "/dynamic") {
+		t.Errorf("expected unresolved-method diagnostic for /dynamic, got %v", p.Diagnostics)
+	}
+}
@@ -0,0 +1,424 @@
+package swaggo
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// 內建支援的 Web 框架
+const (
+	FrameworkGin     = "gin"
+	FrameworkEcho    = "echo"
+	FrameworkNetHTTP = "nethttp"
+	FrameworkChi     = "chi"
+	FrameworkFiber   = "fiber"
+	FrameworkGorilla = "gorilla"
+)
+
+// Framework 描述一個 Web 框架的路由與 handler 慣例
+// 內建 gin、echo、chi、fiber、gorilla/mux 與 net/http，自訂的 router 包裝可實作此介面後以 Generator.WithFramework 加入
+type Framework interface {
+	// Name 框架名稱
+	Name() string
+	// Match 依檔案 import 的 package path 判斷檔案是否使用此框架
+	Match(importPaths []string) bool
+	// ParseRoute 解析路由註冊呼叫，例如 r.GET("/x", h)；不是路由時回傳 nil
+	ParseRoute(call *ast.CallExpr) []*RouteCall
+	// ParseGroup 解析 group 建構呼叫，例如 r.Group("/api", mw...)；不是 group 時回傳 nil
+	ParseGroup(call *ast.CallExpr) *GroupCall
+	// RouterParamType 判斷函數參數型別是否為 router，回傳型別名稱；不是時回傳空字串
+	RouterParamType(expr ast.Expr) string
+	// IsHandler 判斷函數簽名是否為 handler，例如 func(c *gin.Context)
+	IsHandler(fn *ast.FuncType) bool
+	// IsHandlerType 判斷具名型別是否為 handler，例如 gin.HandlerFunc，用於辨識閉包工廠
+	IsHandlerType(expr ast.Expr) bool
+	// ContextMethods 回傳 handler context 上方法的語意，key 為方法名稱；內建框架回傳 nil
+	ContextMethods() map[string]ContextMethod
+}
+
+// RouteCall 路由註冊呼叫的解析結果
+type RouteCall struct {
+	Method      string
+	Path        string
+	Handler     ast.Expr
+	Middlewares []ast.Expr
+	Queries     []string // 路由層級要求的 query 參數，例如 gorilla/mux 的 Queries()
+	Wrapper     string   // 拆開的 net/http 包裝函數，例如 gin.WrapF
+}
+
+// GroupCall group 建構呼叫的解析結果
+type GroupCall struct {
+	Parent      ast.Expr // 上層 router，例如 r.Group(...) 的 r
+	Prefix      string
+	Middlewares []ast.Expr
+}
+
+// ContextMethod 的種類
+const (
+	ContextPathParam  = "path"
+	ContextQueryParam = "query"
+	ContextHeader     = "header"
+	ContextFormParam  = "formData"
+	ContextBody       = "body"
+	ContextResponse   = "response"
+)
+
+// ContextMethod 描述 handler context 上某個方法的語意
+// 例如 ctx.PathInt("id") → {Kind: ContextPathParam, Type: "integer"}
+// ctx.Created(resp) → {Kind: ContextResponse, Status: 201}
+type ContextMethod struct {
+	Kind   string
+	Arg    int    // 參數名、綁定目標或回應內容所在的引數位置；ContextResponse 為 -1 時沒有回應內容
+	Status int    // ContextResponse 的固定 status code；0 表示由第一個引數決定
+	Type   string // 參數型別，空字串視為 string
+}
+
+// builtinFrameworks 內建框架，依比對順序排列；net/http 放最後，只有沒有其他框架時才採用
+func (p *Parser) builtinFrameworks() []Framework {
+	return []Framework{
+		&echoFramework{p: p},
+		&chiFramework{p: p},
+		&fiberFramework{p: p},
+		&gorillaFramework{p: p},
+		&ginFramework{p: p},
+		&netHTTPFramework{p: p},
+	}
+}
+
+// fileFramework 依檔案的 import 判斷使用的框架，自訂框架優先，無法判斷時視為 gin
+func (p *Parser) fileFramework(file *ast.File) Framework {
+	if file != nil {
+		importPaths := make([]string, 0, len(file.Imports))
+		for _, imp := range file.Imports {
+			importPaths = append(importPaths, strings.Trim(imp.Path.Value, `"`))
+		}
+		for _, fw := range p.frameworks {
+			if !fw.Match(importPaths) {
+				continue
+			}
+			// 只 import net/http 不代表以 ServeMux 註冊路由，例如 router 由自家 package 回傳 *gin.Engine
+			if _, ok := fw.(*netHTTPFramework); ok && !usesServeMux(file) {
+				continue
+			}
+			return fw
+		}
+	}
+	return &ginFramework{p: p}
+}
+
+// parseRouteCall 依框架解析路由註冊呼叫，例如 r.GET("/x", h) 或 e.Add("GET", "/x", h)
+// Echo 的 Match 會一次註冊多個 method，因此回傳 slice
+func (p *Parser) parseRouteCall(call *ast.CallExpr, framework Framework) []*RouteCall {
+	if _, ok := call.Fun.(*ast.SelectorExpr); !ok {
+		return nil
+	}
+	return framework.ParseRoute(call)
+}
+
+// isHandlerDecl 判斷函數宣告是否為任一框架的 handler
+func (p *Parser) isHandlerDecl(fn *ast.FuncDecl) bool {
+	for _, fw := range p.frameworks {
+		if fw.IsHandler(fn.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// isHandlerType 判斷具名型別是否為任一框架的 handler 型別
+func (p *Parser) isHandlerType(expr ast.Expr) bool {
+	for _, fw := range p.frameworks {
+		if fw.IsHandlerType(expr) {
+			return true
+		}
+	}
+	return false
+}
+
+// routerParamType 判斷參數型別是否為任一框架的 router
+func (p *Parser) routerParamType(expr ast.Expr) string {
+	for _, fw := range p.frameworks {
+		if paramType := fw.RouterParamType(expr); paramType != "" {
+			return paramType
+		}
+	}
+	return ""
+}
+
+// contextMethod 查詢自訂框架宣告的 context 方法
+// 只查 call 所在檔案使用的框架，避免其他框架的同名方法改變 handler 呼叫的語意
+func (p *Parser) contextMethod(call *ast.CallExpr, name string) (ContextMethod, bool) {
+	cm, ok := p.fileFramework(p.fileAt(call.Pos())).ContextMethods()[name]
+	return cm, ok
+}
+
+// applyContextMethod 依 ContextMethod 的宣告記錄參數、request body 或回應
+func (p *Parser) applyContextMethod(call *ast.CallExpr, cm ContextMethod, handler *HandlerInfo, localVarTypes map[string]string) {
+	switch cm.Kind {
+	case ContextPathParam, ContextQueryParam, ContextHeader, ContextFormParam:
+		if cm.Arg >= len(call.Args) {
+			return
+		}
+		name := p.extractStringArg(call.Args[cm.Arg])
+		if name == "" {
+			return
+		}
+		paramType := cm.Type
+		if paramType == "" {
+			paramType = "string"
+		}
+		handler.Parameters = append(handler.Parameters, &ParameterInfo{
+			Name:     name,
+			Type:     paramType,
+			In:       cm.Kind,
+			Required: cm.Kind == ContextPathParam,
+		})
+
+	case ContextBody:
+		if cm.Arg >= len(call.Args) {
+			return
+		}
+		typeName := p.extractTypeFromBindArgWithLocals(call.Args[cm.Arg], localVarTypes)
+		if typeName == "" {
+			return
+		}
+		if typeInfo := p.findType(typeName); typeInfo != nil {
+			handler.RequestBody = typeInfo
+		} else {
+			handler.RequestBody = &TypeInfo{Name: typeName, Kind: "struct"}
+		}
+
+	case ContextResponse:
+		code := cm.Status
+		if code == 0 && len(call.Args) > 0 {
+			code = p.extractStatusCode(call.Args[0])
+		}
+		if code <= 0 {
+			return
+		}
+		resp := &ResponseInfo{StatusCode: code}
+		if cm.Arg >= 0 && cm.Arg < len(call.Args) {
+			resp.Type, resp.IsArray = p.extractResponseTypeWithLocals(call.Args[cm.Arg], localVarTypes)
+		}
+		handler.Responses[code] = resp
+	}
+}
+
+// ginFramework 內建 gin adapter
+type ginFramework struct{ p *Parser }
+
+func (f *ginFramework) Name() string { return FrameworkGin }
+
+func (f *ginFramework) Match(importPaths []string) bool {
+	return hasImport(importPaths, "github.com/gin-gonic/gin")
+}
+
+func (f *ginFramework) ParseRoute(call *ast.CallExpr) []*RouteCall {
+	return f.p.parseGinRouteCall(call, call.Fun.(*ast.SelectorExpr).Sel.Name)
+}
+
+func (f *ginFramework) ParseGroup(call *ast.CallExpr) *GroupCall {
+	return f.p.parseGroupCall(call)
+}
+
+func (f *ginFramework) RouterParamType(expr ast.Expr) string { return f.p.getGinParamType(expr) }
+
+// IsHandler 沿用原本寬鬆的判斷：任何 Context 參數（gin.Context、echo.Context 等）都視為 handler
+func (f *ginFramework) IsHandler(fn *ast.FuncType) bool {
+	if fn.Params == nil {
+		return false
+	}
+	for _, param := range fn.Params.List {
+		if strings.Contains(f.p.typeToString(param.Type), "Context") {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *ginFramework) IsHandlerType(expr ast.Expr) bool {
+	return isSelector(expr, "gin", "HandlerFunc")
+}
+
+func (f *ginFramework) ContextMethods() map[string]ContextMethod { return nil }
+
+// hasImport 判斷 import 清單是否包含 path 或其子版本（例如 path/v5）
+func hasImport(importPaths []string, path string) bool {
+	for _, imp := range importPaths {
+		if imp == path || strings.HasPrefix(imp, path+"/v") {
+			return true
+		}
+	}
+	return false
+}
+
+// isSelector 判斷 expr 是否為 pkg.Name
+func isSelector(expr ast.Expr, pkg, name string) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != name {
+		return false
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	return ok && ident.Name == pkg
+}
+
+// parseGinRouteCall 解析 gin 的 GET(path, ...handlers) 與 Handle(method, path, ...handlers)
+// 最後一個引數是 handler，中間的是 middleware
+func (p *Parser) parseGinRouteCall(call *ast.CallExpr, method string) []*RouteCall {
+	if !isHTTPMethod(method) {
+		return nil
+	}
+
+	// Handle() 的簽名是 Handle(method, path, ...handlers)，需要至少 3 個引數
+	// 其他 HTTP method 的簽名是 GET(path, ...handlers)，需要至少 2 個引數
+	start := 1
+	rc := &RouteCall{Method: method}
+	if method == "Handle" {
+		if len(call.Args) < 3 {
+			return nil
+		}
+		rc.Method = p.extractHTTPMethodArg(call.Args[0])
+		rc.Path = p.extractStringArg(call.Args[1])
+		start = 2
+	} else {
+		if len(call.Args) < 2 {
+			return nil
+		}
+		rc.Path = p.extractStringArg(call.Args[0])
+	}
+
+	rc.Handler = call.Args[len(call.Args)-1]
+	rc.Middlewares = call.Args[start : len(call.Args)-1]
+	return p.unwrapWrappedHandlers([]*RouteCall{rc})
+}
+
+// unwrapWrappedHandlers 拆開 gin.WrapH / gin.WrapF / echo.WrapHandler 包裝的 net/http handler
+// 例如 gin.WrapH(promhttp.Handler())、gin.WrapF(legacy.Create)
+// 包裝內的 net/http middleware 會接在路由 middleware 之後
+func (p *Parser) unwrapWrappedHandlers(calls []*RouteCall) []*RouteCall {
+	for _, rc := range calls {
+		call, ok := rc.Handler.(*ast.CallExpr)
+		if !ok || len(call.Args) != 1 {
+			continue
+		}
+		if !isSelector(call.Fun, "gin", "WrapH") && !isSelector(call.Fun, "gin", "WrapF") && !isSelector(call.Fun, "echo", "WrapHandler") {
+			continue
+		}
+		handler, middlewares := p.unwrapNetHTTPHandler(call.Args[0])
+		sel := call.Fun.(*ast.SelectorExpr)
+		rc.Wrapper = sel.X.(*ast.Ident).Name + "." + sel.Sel.Name
+		rc.Handler = handler
+		rc.Middlewares = append(append([]ast.Expr{}, rc.Middlewares...), middlewares...)
+	}
+	return calls
+}
+
+// newRouteInfo 以 group prefix 與 RouteCall 建立 RouteInfo
+// {id}、{id:[0-9]+} 等 wildcard 會統一轉成 gin 風格，regex 限制保留在 PathPatterns
+func (p *Parser) newRouteInfo(rc *RouteCall, groupPrefix, handlerName string, middlewares []*MiddlewareInfo) *RouteInfo {
+	path, patterns := convertBracePath(groupPrefix + rc.Path)
+	group, _ := convertBracePath(groupPrefix)
+
+	return &RouteInfo{
+		Method:       rc.Method,
+		Path:         path,
+		HandlerName:  handlerName,
+		Group:        group,
+		Middlewares:  middlewares,
+		PathPatterns: patterns,
+		QueryParams:  rc.Queries,
+	}
+}
+
+// registeredFunc 回傳註冊路由時實際產生 handler 的函數：net/http 包裝函數、泛型 adapter
+// 或工廠（工廠回傳具名 handler 時為該函數）；直接傳入 handler 時回傳空字串
+func (p *Parser) registeredFunc(rc *RouteCall, pkgName string) string {
+	if rc.Wrapper != "" {
+		return rc.Wrapper
+	}
+	call, ok := rc.Handler.(*ast.CallExpr)
+	if !ok {
+		return ""
+	}
+	if adapter := p.typedAdapterName(call, pkgName); adapter != "" {
+		return adapter
+	}
+	factory, ok := p.closureFactories[calledFuncName(call, pkgName)]
+	if !ok {
+		return ""
+	}
+	if factory.Returns != "" {
+		return factory.Returns
+	}
+	return factory.FullName
+}
+
+// calledFuncName 回傳 call 呼叫的函數名稱，泛型實例化會去掉型別引數，
+// 例如 MakeHandler[User]() → pkg.MakeHandler；方法呼叫等無法判斷時回傳空字串
+func calledFuncName(call *ast.CallExpr, currentPkg string) string {
+	fun := call.Fun
+	switch f := fun.(type) {
+	case *ast.IndexExpr:
+		fun = f.X
+	case *ast.IndexListExpr:
+		fun = f.X
+	}
+
+	switch f := fun.(type) {
+	case *ast.Ident:
+		return currentPkg + "." + f.Name
+	case *ast.SelectorExpr:
+		if pkg, ok := f.X.(*ast.Ident); ok {
+			return pkg.Name + "." + f.Sel.Name
+		}
+	}
+	return ""
+}
+
+// collectMountPrefixes 收集把子 router 掛到某個 prefix 下的寫法，回傳子 router 變數名 → prefix
+//
+//	mux.Handle("/api/", http.StripPrefix("/api", sub))
+//	r.Mount("/admin", adminRouter)
+func (p *Parser) collectMountPrefixes(file *ast.File) map[string]string {
+	mounts := make(map[string]string)
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) != 2 {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		var prefix string
+		var child ast.Expr
+		switch {
+		case sel.Sel.Name == "Handle" && isStripPrefixCall(call.Args[1]):
+			strip := call.Args[1].(*ast.CallExpr)
+			if len(strip.Args) != 2 {
+				return true
+			}
+			prefix, child = p.extractStringArg(strip.Args[0]), strip.Args[1]
+		case sel.Sel.Name == "Mount":
+			prefix, child = p.extractStringArg(call.Args[0]), call.Args[1]
+		default:
+			return true
+		}
+
+		ident, ok := child.(*ast.Ident)
+		if !ok || prefix == "" {
+			return true
+		}
+
+		parentPrefix := ""
+		if parent, ok := routerReceiver(sel.X).(*ast.Ident); ok {
+			parentPrefix = mounts[parent.Name]
+		}
+		mounts[ident.Name] = parentPrefix + strings.TrimSuffix(prefix, "/")
+		return true
+	})
+
+	return mounts
+}
@@ -0,0 +1,97 @@
+package swaggo
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+)
+
+// FrameworkSpec 以宣告方式描述自訂框架，適用於「路由方法第一個引數是 path、最後一個是 handler」的常見寫法
+//
+//	swaggo.New().WithFramework(swaggo.FrameworkSpec{
+//		FrameworkName: "rest",
+//		ImportPaths:   []string{"example.com/rest"},
+//		RouteMethods:  map[string]string{"Read": "GET", "Write": "POST"},
+//		GroupMethods:  []string{"Prefix"},
+//		RouterTypes:   []string{"*rest.Router"},
+//		HandlerParams: []string{"*rest.Ctx"},
+//		Context: map[string]swaggo.ContextMethod{
+//			"PathInt": {Kind: swaggo.ContextPathParam, Type: "integer"},
+//			"OK":      {Kind: swaggo.ContextResponse, Status: 200},
+//		},
+//	})
+//
+// 型別以原始碼寫法比對，例如 "*rest.Router"、"rest.HandlerFunc"
+type FrameworkSpec struct {
+	FrameworkName string
+	ImportPaths   []string                 // 使用此框架的檔案會 import 的 package path
+	RouteMethods  map[string]string        // 路由方法名稱 → HTTP method，"Any" 表示不限 method
+	GroupMethods  []string                 // group 方法名稱，簽名為 (prefix, ...middlewares)
+	RouterTypes   []string                 // 可作為路由註冊函數參數的 router 型別
+	HandlerParams []string                 // handler 參數型別，函數有此參數即視為 handler
+	HandlerTypes  []string                 // handler 的具名型別，回傳此型別的函數視為閉包工廠
+	Context       map[string]ContextMethod // handler context 上方法的語意
+
+	p *Parser // AddFramework 時設定
+}
+
+func (s FrameworkSpec) Name() string { return s.FrameworkName }
+
+func (s FrameworkSpec) Match(importPaths []string) bool {
+	for _, path := range s.ImportPaths {
+		if hasImport(importPaths, path) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s FrameworkSpec) ParseRoute(call *ast.CallExpr) []*RouteCall {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	method, ok := s.RouteMethods[sel.Sel.Name]
+	if !ok || len(call.Args) < 2 {
+		return nil
+	}
+	return []*RouteCall{{
+		Method:      method,
+		Path:        s.p.extractStringArg(call.Args[0]),
+		Handler:     call.Args[len(call.Args)-1],
+		Middlewares: call.Args[1 : len(call.Args)-1],
+	}}
+}
+
+func (s FrameworkSpec) ParseGroup(call *ast.CallExpr) *GroupCall {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || len(call.Args) == 0 || !slices.Contains(s.GroupMethods, sel.Sel.Name) {
+		return nil
+	}
+	return &GroupCall{Parent: sel.X, Prefix: s.p.extractStringArg(call.Args[0]), Middlewares: call.Args[1:]}
+}
+
+func (s FrameworkSpec) RouterParamType(expr ast.Expr) string {
+	if slices.Contains(s.RouterTypes, types.ExprString(expr)) {
+		return types.ExprString(expr)
+	}
+	return ""
+}
+
+func (s FrameworkSpec) IsHandler(fn *ast.FuncType) bool {
+	if fn.Params == nil {
+		return false
+	}
+	for _, param := range fn.Params.List {
+		if slices.Contains(s.HandlerParams, types.ExprString(param.Type)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s FrameworkSpec) IsHandlerType(expr ast.Expr) bool {
+	return slices.Contains(s.HandlerTypes, types.ExprString(expr))
+}
+
+func (s FrameworkSpec) ContextMethods() map[string]ContextMethod { return s.Context }
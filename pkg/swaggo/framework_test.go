@@ -0,0 +1,184 @@
+package swaggo
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestCustomFrameworkSpec(t *testing.T) {
+	src := `package main
+
+import "example.com/rest"
+
+type User struct {
+	ID   int    ` + "`json:\"id\"`" + `
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type CreateUserRequest struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type UserHandler struct{}
+
+func (h *UserHandler) Get(ctx *rest.Ctx) error {
+	_ = ctx.PathInt("id")
+	_ = ctx.Query("fields")
+	return ctx.OK(User{})
+}
+
+func (h *UserHandler) Create(ctx *rest.Ctx) error {
+	var req CreateUserRequest
+	ctx.BindBody(&req)
+	return ctx.Reply(201, User{})
+}
+
+func RegisterUsers(r *rest.Router, h *UserHandler) {
+	users := r.Prefix("/users")
+	users.Read("/:id", h.Get)
+	users.Write("", h.Create)
+}
+
+func main() {
+	r := rest.New()
+	api := r.Prefix("/api")
+	RegisterUsers(api, &UserHandler{})
+}
+`
+	p := NewParser()
+	p.AddFramework(FrameworkSpec{
+		FrameworkName: "rest",
+		ImportPaths:   []string{"example.com/rest"},
+		RouteMethods:  map[string]string{"Read": "GET", "Write": "POST"},
+		GroupMethods:  []string{"Prefix"},
+		RouterTypes:   []string{"*rest.Router"},
+		HandlerParams: []string{"*rest.Ctx"},
+		Context: map[string]ContextMethod{
+			"PathInt":  {Kind: ContextPathParam, Type: "integer"},
+			"Query":    {Kind: ContextQueryParam},
+			"BindBody": {Kind: ContextBody},
+			"OK":       {Kind: ContextResponse, Status: 200},
+			"Reply":    {Kind: ContextResponse, Arg: 1},
+		},
+	})
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "main.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	p.fset = fset
+	p.files = append(p.files, file)
+	if err := p.Analyze(); err != nil {
+		t.Fatalf("analyze error: %v", err)
+	}
+
+	get := findRoute(p, "GET", "/api/users/:id")
+	if get == nil || get.Handler == nil {
+		for _, route := range p.Routes {
+			t.Logf("  found: %s %s -> %s", route.Method, route.Path, route.HandlerName)
+		}
+		t.Fatal("expected GET /api/users/:id with handler")
+	}
+	var hasPath, hasQuery bool
+	for _, param := range get.Handler.Parameters {
+		if param.In == "path" && param.Name == "id" && param.Type == "integer" && param.Required {
+			hasPath = true
+		}
+		if param.In == "query" && param.Name == "fields" {
+			hasQuery = true
+		}
+	}
+	if !hasPath || !hasQuery {
+		t.Errorf("expected integer path param id and query param fields, got %+v", get.Handler.Parameters)
+	}
+	if resp := get.Handler.Responses[200]; resp == nil || resp.Type == nil || resp.Type.Name != "User" {
+		t.Errorf("expected 200 User response, got %+v", get.Handler.Responses)
+	}
+
+	create := findRoute(p, "POST", "/api/users")
+	if create == nil || create.Handler == nil {
+		t.Fatal("expected POST /api/users with handler")
+	}
+	if create.Handler.RequestBody == nil || create.Handler.RequestBody.Name != "CreateUserRequest" {
+		t.Errorf("expected CreateUserRequest body, got %+v", create.Handler.RequestBody)
+	}
+	if resp := create.Handler.Responses[201]; resp == nil || resp.Type == nil || resp.Type.Name != "User" {
+		t.Errorf("expected 201 User response, got %+v", create.Handler.Responses)
+	}
+}
+
+func TestContextMethodsScopedToFileFramework(t *testing.T) {
+	sources := map[string]string{
+		"rest.go": `package main
+
+import "example.com/rest"
+
+func Created(ctx *rest.Ctx) error { return ctx.JSON(User{}) }
+
+func registerRest(r *rest.Router) {
+	r.Write("/rest/users", Created)
+}
+`,
+		"gin.go": `package main
+
+import "github.com/gin-gonic/gin"
+
+type User struct {
+	ID int ` + "`json:\"id\"`" + `
+}
+
+func GetUser(c *gin.Context) { c.JSON(200, User{}) }
+
+func main() {
+	r := gin.Default()
+	r.GET("/gin/users", GetUser)
+	registerRest(rest.New())
+}
+`,
+	}
+
+	p := NewParser()
+	p.AddFramework(FrameworkSpec{
+		FrameworkName: "rest",
+		ImportPaths:   []string{"example.com/rest"},
+		RouteMethods:  map[string]string{"Write": "POST"},
+		RouterTypes:   []string{"*rest.Router"},
+		HandlerParams: []string{"*rest.Ctx"},
+		Context: map[string]ContextMethod{
+			"JSON": {Kind: ContextResponse, Status: 201},
+		},
+	})
+	fset := token.NewFileSet()
+	for name, src := range sources {
+		file, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+		if err != nil {
+			t.Fatalf("parse %s: %v", name, err)
+		}
+		p.files = append(p.files, file)
+	}
+	p.fset = fset
+	if err := p.Analyze(); err != nil {
+		t.Fatalf("analyze error: %v", err)
+	}
+
+	get := findRoute(p, "GET", "/gin/users")
+	if get == nil || get.Handler == nil {
+		t.Fatal("expected GET /gin/users with handler")
+	}
+	if _, ok := get.Handler.Responses[201]; ok {
+		t.Errorf("rest context method applied to a gin handler: %+v", get.Handler.Responses)
+	}
+	if resp := get.Handler.Responses[200]; resp == nil || resp.Type == nil || resp.Type.Name != "User" {
+		t.Errorf("expected gin 200 User response, got %+v", get.Handler.Responses)
+	}
+
+	create := findRoute(p, "POST", "/rest/users")
+	if create == nil || create.Handler == nil {
+		t.Fatal("expected POST /rest/users with handler")
+	}
+	if _, ok := create.Handler.Responses[201]; !ok {
+		t.Errorf("expected 201 from rest context method, got %+v", create.Handler.Responses)
+	}
+}
@@ -19,6 +19,10 @@ type Generator struct {
 	excludeDirs     []string
 	parseVendor     bool
 	parseDependency bool
+	oauth2TokenURL  string
+	strict          bool
+	sourceExt       bool   // 輸出 x-source
+	sourceRoot      string // x-source 路徑的基準目錄
 
 	parser *Parser
 }
@@ -87,6 +91,67 @@ func (g *Generator) WithProjectRoot(root string) *Generator {
 	return g
 }
 
+// WithAuthorizationRules 追加授權 middleware 辨識規則（內建規則仍有效）
+func (g *Generator) WithAuthorizationRules(rules ...AuthorizationRule) *Generator {
+	g.parser.authorizationRules = append(g.parser.authorizationRules, rules...)
+	return g
+}
+
+// WithFramework 加入自訂框架 adapter，用於內建框架以外的 router 或公司內部的包裝
+func (g *Generator) WithFramework(fw Framework) *Generator {
+	g.parser.AddFramework(fw)
+	return g
+}
+
+// WithThirdPartyRegistrars 追加第三方 registrar（內建的仍有效）
+func (g *Generator) WithThirdPartyRegistrars(regs ...ThirdPartyRegistrar) *Generator {
+	g.parser.thirdPartyRegistrars = append(g.parser.thirdPartyRegistrars, regs...)
+	return g
+}
+
+// WithThirdPartyRoutes 設定第三方 registrar 路由的處理方式：ThirdPartyDocument、ThirdPartyInternal 或 ThirdPartyExclude
+func (g *Generator) WithThirdPartyRoutes(mode string) *Generator {
+	g.parser.thirdPartyMode = mode
+	return g
+}
+
+// WithBuildTags 設定評估 //go:build 條件時啟用的 build tag，例如 enterprise
+func (g *Generator) WithBuildTags(tags ...string) *Generator {
+	g.parser.buildContext.BuildTags = append(g.parser.buildContext.BuildTags, tags...)
+	return g
+}
+
+// WithTarget 設定評估 build constraint 的目標平台，空字串表示沿用目前環境
+func (g *Generator) WithTarget(goos, goarch string) *Generator {
+	if goos != "" {
+		g.parser.buildContext.GOOS = goos
+	}
+	if goarch != "" {
+		g.parser.buildContext.GOARCH = goarch
+	}
+	return g
+}
+
+// WithIncludeConditions 只保留條件符合 pattern 的條件式路由（* 為萬用字元），沒有條件的路由不受影響
+func (g *Generator) WithIncludeConditions(patterns ...string) *Generator {
+	g.parser.includeConditions = append(g.parser.includeConditions, patterns...)
+	return g
+}
+
+// WithExcludeConditions 排除任一條件符合 pattern 的路由（* 為萬用字元），例如 gin.Mode() == gin.DebugMode
+func (g *Generator) WithExcludeConditions(patterns ...string) *Generator {
+	g.parser.excludeConditions = append(g.parser.excludeConditions, patterns...)
+	return g
+}
+
+// WithOAuth2TokenURL 設定 scope 推斷出的 oauth2 security scheme 的 token URL，
+// 未設定時不輸出 oauth2 scheme，scope 改以 x-scopes 輸出並記錄 diagnostic
+func (g *Generator) WithOAuth2TokenURL(url string) *Generator {
+	g.oauth2TokenURL = url
+	g.parser.oauth2TokenURL = url
+	return g
+}
+
 func (g *Generator) SetParseVendor(v bool) {
 	g.parseVendor = v
 	g.parser.parseVendor = v
@@ -97,6 +162,32 @@ func (g *Generator) SetParseDependency(v bool) {
 	g.parser.parseDependency = v
 }
 
+// SetPruneUnreachable 只保留由 main 可到達的路由註冊，移除的路由記錄在 Diagnostics
+func (g *Generator) SetPruneUnreachable(v bool) {
+	g.parser.pruneUnreachable = v
+}
+
+// SetStrict 啟用 strict 模式：語法錯誤、handler 無法解析或 $ref 指向不存在的 schema 時產生失敗
+func (g *Generator) SetStrict(v bool) {
+	g.strict = v
+}
+
+// SetSourceExtensions 在 operation、參數、request body 與回應輸出 x-source: file:line，
+// 路徑相對於專案根目錄
+func (g *Generator) SetSourceExtensions(v bool) {
+	g.sourceExt = v
+}
+
+// Routes 回傳分析出的路由
+func (g *Generator) Routes() []*RouteInfo {
+	return g.parser.Routes
+}
+
+// Diagnostics 回傳分析過程中記錄的 diagnostic
+func (g *Generator) Diagnostics() []Diagnostic {
+	return g.parser.Diagnostics
+}
+
 func (g *Generator) Stats() Stats {
 	return Stats{
 		Routes:   len(g.parser.Routes),
@@ -127,9 +218,13 @@ func (g *Generator) Parse() error {
 	return g.ParseSource(root)
 }
 
+// ParseSource 解析目錄；非 strict 模式下語法錯誤只記錄在 Diagnostics，其餘檔案照常分析
 func (g *Generator) ParseSource(paths ...string) error {
+	if len(paths) > 0 {
+		g.sourceRoot = paths[0]
+	}
 	for _, path := range paths {
-		if err := g.parser.ParseDir(path); err != nil {
+		if err := g.parser.ParseDir(path); err != nil && (g.strict || !IsParseError(err)) {
 			return err
 		}
 	}
@@ -138,7 +233,8 @@ func (g *Generator) ParseSource(paths ...string) error {
 
 // ParseFromEntry 從指定入口檔案解析，只追蹤被 import 的 package
 func (g *Generator) ParseFromEntry(entryFile string, projectRoot string) error {
-	if err := g.parser.ParseFromEntry(entryFile, projectRoot); err != nil {
+	g.sourceRoot = projectRoot
+	if err := g.parser.ParseFromEntry(entryFile, projectRoot); err != nil && (g.strict || !IsParseError(err)) {
 		return err
 	}
 	return g.parser.Analyze()
@@ -168,6 +264,7 @@ func (g *Generator) Generate() (*OpenAPI, error) {
 		})
 	}
 
+	var operations []routeOperation
 	for _, route := range g.parser.Routes {
 		path := convertGinPathToOpenAPI(route.Path)
 
@@ -176,23 +273,25 @@ func (g *Generator) Generate() (*OpenAPI, error) {
 			pathItem = PathItem{}
 		}
 
-		op := g.routeToOperation(route)
-
-		switch strings.ToUpper(route.Method) {
-		case "GET":
-			pathItem.Get = op
-		case "POST":
-			pathItem.Post = op
-		case "PUT":
-			pathItem.Put = op
-		case "DELETE":
-			pathItem.Delete = op
-		case "PATCH":
-			pathItem.Patch = op
-		case "OPTIONS":
-			pathItem.Options = op
-		case "HEAD":
-			pathItem.Head = op
+		isAny := strings.EqualFold(route.Method, "Any")
+		methods := []string{strings.ToUpper(route.Method)}
+		if isAny {
+			methods = anyOperationMethods
+		}
+		for _, method := range methods {
+			// 同一 path 明確指定 method 的路由優先於 Any 展開
+			if isAny && pathItem.operation(method) != nil {
+				continue
+			}
+			op := g.routeToOperation(route)
+			if isAny && op.OperationID != "" {
+				op.OperationID += "_" + strings.ToLower(method)
+			}
+			if !pathItem.setOperation(method, op) {
+				continue
+			}
+			g.applySecurity(spec, route, op)
+			operations = append(operations, routeOperation{route, op})
 		}
 
 		spec.Paths[path] = pathItem
@@ -210,9 +309,19 @@ func (g *Generator) Generate() (*OpenAPI, error) {
 		}
 	}
 
+	g.checkSchemaRefs(spec, operations)
+	if g.strict {
+		if problems := g.strictProblems(); len(problems) > 0 {
+			return nil, &StrictError{Diagnostics: problems}
+		}
+	}
+
 	return spec, nil
 }
 
+// anyOperationMethods 不限 method 的路由（gin Any、fiber All、chi Handle、沒有 method 的 ServeMux pattern 等）展開成的 operation
+var anyOperationMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
 func (g *Generator) routeToOperation(route *RouteInfo) *Operation {
 	op := &Operation{
 		Responses: make(map[string]Response),
@@ -223,8 +332,21 @@ func (g *Generator) routeToOperation(route *RouteInfo) *Operation {
 		op.Description = route.Handler.Description
 		op.OperationID = g.generateOperationID(route.Handler)
 
+		var formParams []*ParameterInfo
 		for _, param := range route.Handler.Parameters {
-			op.Parameters = append(op.Parameters, g.paramToOpenAPI(param))
+			if isCredentialParam(param, route.Security) {
+				continue
+			}
+			if param.In == "formData" {
+				formParams = append(formParams, param)
+				continue
+			}
+			parameter := g.paramToOpenAPI(param)
+			if pattern, ok := route.PathPatterns[param.Name]; ok && param.In == "path" {
+				parameter.Schema.Pattern = pattern
+			}
+			parameter.XSource = g.sourceRef(param.Position)
+			op.Parameters = append(op.Parameters, parameter)
 		}
 
 		if route.Handler.RequestBody != nil {
@@ -235,11 +357,26 @@ func (g *Generator) routeToOperation(route *RouteInfo) *Operation {
 						Schema: g.typeRefOrInline(route.Handler.RequestBody),
 					},
 				},
+				XSource: g.sourceRef(route.Handler.RequestBodyPosition),
+			}
+		}
+
+		if len(formParams) > 0 {
+			if op.RequestBody == nil {
+				op.RequestBody = &RequestBody{
+					Content: make(map[string]MediaType),
+					XSource: g.sourceRef(formParams[0].Position),
+				}
+			}
+			op.RequestBody.Content["application/x-www-form-urlencoded"] = MediaType{
+				Schema: g.formParamsToSchema(formParams),
 			}
 		}
 
 		for code, resp := range route.Handler.Responses {
-			op.Responses[statusCodeToString(code)] = g.responseToOpenAPI(resp)
+			response := g.responseToOpenAPI(resp)
+			response.XSource = g.sourceRef(resp.Position)
+			op.Responses[statusCodeToString(code)] = response
 		}
 	}
 
@@ -249,18 +386,99 @@ func (g *Generator) routeToOperation(route *RouteInfo) *Operation {
 		}
 	}
 
-	if route.Group != "" {
-		tag := strings.Trim(route.Group, "/")
-		if tag != "" {
-			parts := strings.Split(tag, "/")
-			tag = parts[len(parts)-1]
-			op.Tags = []string{tag}
-		}
+	op.Tags = route.Tags()
+
+	if len(route.Conditions) > 0 {
+		op.XCondition = conditionExpression(route.Conditions)
 	}
+	op.XSource = g.sourceRef(route.Position)
 
 	return op
 }
 
+// applySecurity 把路由推斷出的認證方式寫入 securitySchemes 與 operation.security
+func (g *Generator) applySecurity(spec *OpenAPI, route *RouteInfo, op *Operation) {
+	op.XRoles = route.Roles
+
+	if len(route.Security) == 0 && len(route.Scopes) == 0 {
+		return
+	}
+
+	if spec.Components.SecuritySchemes == nil {
+		spec.Components.SecuritySchemes = make(map[string]*SecurityScheme)
+	}
+
+	if len(route.Security) > 0 {
+		requirement := SecurityRequirement{}
+		for _, info := range route.Security {
+			name := securitySchemeName(info)
+			if _, exists := spec.Components.SecuritySchemes[name]; !exists {
+				spec.Components.SecuritySchemes[name] = g.securityToOpenAPI(info)
+			}
+			requirement[name] = []string{}
+		}
+		op.Security = append(op.Security, requirement)
+	}
+
+	// oauth2 是另一組可選的 requirement；沒有 token URL 時 clientCredentials flow 不合法，只輸出 x-scopes
+	if len(route.Scopes) > 0 {
+		if g.oauth2TokenURL == "" {
+			op.XScopes = route.Scopes
+			return
+		}
+		g.registerOAuth2Scopes(spec, route.Scopes)
+		op.Security = append(op.Security, SecurityRequirement{"oauth2": route.Scopes})
+	}
+}
+
+// registerOAuth2Scopes 把路由用到的 scope 彙整到 oauth2 security scheme
+func (g *Generator) registerOAuth2Scopes(spec *OpenAPI, scopes []string) {
+	scheme, ok := spec.Components.SecuritySchemes["oauth2"]
+	if !ok {
+		scheme = &SecurityScheme{
+			Type:        "oauth2",
+			Description: "Inferred from authorization middleware scopes",
+			Flows: &OAuthFlows{
+				ClientCredentials: &OAuthFlow{
+					TokenURL: g.oauth2TokenURL,
+					Scopes:   make(map[string]string),
+				},
+			},
+		}
+		spec.Components.SecuritySchemes["oauth2"] = scheme
+	}
+	for _, scope := range scopes {
+		scheme.Flows.ClientCredentials.Scopes[scope] = ""
+	}
+}
+
+func (g *Generator) securityToOpenAPI(info *SecurityInfo) *SecurityScheme {
+	scheme := &SecurityScheme{
+		Type:         info.Type,
+		Scheme:       info.Scheme,
+		BearerFormat: info.BearerFormat,
+		In:           info.In,
+		Name:         info.Name,
+	}
+	if info.Middleware != "" {
+		scheme.Description = "Inferred from middleware " + info.Middleware
+	}
+	return scheme
+}
+
+// isCredentialParam 判斷參數是否為認證憑證，這類參數由 security 描述而非 parameters
+func isCredentialParam(param *ParameterInfo, security []*SecurityInfo) bool {
+	if param.In == "header" && strings.EqualFold(param.Name, "Authorization") {
+		return true
+	}
+	for _, info := range security {
+		if info.Type == "apiKey" && info.In == param.In && strings.EqualFold(info.Name, param.Name) {
+			return true
+		}
+	}
+	return false
+}
+
 func (g *Generator) paramToOpenAPI(param *ParameterInfo) Parameter {
 	p := Parameter{
 		Name:        param.Name,
@@ -277,6 +495,23 @@ func (g *Generator) paramToOpenAPI(param *ParameterInfo) Parameter {
 	return p
 }
 
+// formParamsToSchema 把表單欄位（c.FormValue 等）組成 form request body 的 schema
+func (g *Generator) formParamsToSchema(params []*ParameterInfo) *Schema {
+	schema := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema),
+	}
+	for _, param := range params {
+		prop := g.primitiveSchema(param.Type)
+		prop.Description = param.Comment
+		schema.Properties[param.Name] = prop
+		if param.Required {
+			schema.Required = append(schema.Required, param.Name)
+		}
+	}
+	return schema
+}
+
 func (g *Generator) generateOperationID(handler *HandlerInfo) string {
 	if handler.Receiver != "" {
 		return handler.Receiver + "_" + handler.Name
@@ -301,8 +536,12 @@ func (g *Generator) responseToOpenAPI(resp *ResponseInfo) Response {
 				Items: schema,
 			}
 		}
+		contentType := resp.ContentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
 		r.Content = map[string]MediaType{
-			"application/json": {Schema: schema},
+			contentType: {Schema: schema},
 		}
 	}
 
@@ -399,6 +638,8 @@ func (g *Generator) primitiveSchema(goType string) *Schema {
 		return &Schema{Type: "boolean"}
 	case "time.Time":
 		return &Schema{Type: "string", Format: "date-time"}
+	case "binary", "[]byte":
+		return &Schema{Type: "string", Format: "binary"}
 	case "interface{}", "any":
 		return &Schema{}
 	default:
@@ -439,32 +680,14 @@ func (g *Generator) isTypeReferenced(name string) bool {
 }
 
 func convertGinPathToOpenAPI(path string) string {
-	result := strings.ReplaceAll(path, ":", "{")
-
-	var output strings.Builder
-	inParam := false
-	for i, c := range result {
-		if c == '{' {
-			inParam = true
-			output.WriteRune(c)
-		} else if inParam && (c == '/' || i == len(result)-1) {
-			if i == len(result)-1 && c != '/' {
-				output.WriteRune(c)
-			}
-			output.WriteRune('}')
-			if c == '/' {
-				output.WriteRune(c)
-			}
-			inParam = false
-		} else {
-			output.WriteRune(c)
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		// :id 與 catch-all 的 *filepath 都轉成 {name}
+		if len(seg) > 1 && (seg[0] == ':' || seg[0] == '*') {
+			segments[i] = "{" + seg[1:] + "}"
 		}
 	}
-
-	res := output.String()
-	res = strings.ReplaceAll(res, "*", "{")
-
-	return res
+	return strings.Join(segments, "/")
 }
 
 func statusCodeToString(code int) string {
@@ -1,6 +1,7 @@
 package swaggo
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -111,3 +112,54 @@ func TestSpecToYAML(t *testing.T) {
 		t.Error("expected non-empty YAML output")
 	}
 }
+
+func TestGenerateExpandsAnyMethodRoutes(t *testing.T) {
+	p := analyzeSource(t, `package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+func listUsers(w http.ResponseWriter, r *http.Request) { json.NewEncoder(w).Encode([]string{}) }
+func userByID(w http.ResponseWriter, r *http.Request)  {}
+func getUser(w http.ResponseWriter, r *http.Request)   {}
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users", listUsers)
+	mux.HandleFunc("/users/{id}", userByID)
+	mux.HandleFunc("GET /users/{id}", getUser)
+	http.ListenAndServe(":8080", mux)
+}
+`)
+
+	gen := New()
+	gen.parser = p
+	spec, err := gen.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	users := spec.Paths["/users"]
+	for _, method := range anyOperationMethods {
+		op := users.operation(method)
+		if op == nil {
+			t.Fatalf("expected %s /users from a pattern without method", method)
+		}
+		if want := "listUsers_" + strings.ToLower(method); op.OperationID != want {
+			t.Errorf("%s operationId = %q, want %q", method, op.OperationID, want)
+		}
+	}
+	if _, ok := users.Get.Responses["200"]; !ok {
+		t.Errorf("expected 200 response on GET /users, got %v", users.Get.Responses)
+	}
+
+	byID := spec.Paths["/users/{id}"]
+	if byID.Get == nil || byID.Get.OperationID != "getUser" {
+		t.Errorf("explicit GET route should win over the catch-all, got %+v", byID.Get)
+	}
+	if byID.Post == nil || byID.Post.OperationID != "userByID_post" {
+		t.Errorf("expected POST from the catch-all route, got %+v", byID.Post)
+	}
+}
@@ -0,0 +1,274 @@
+package swaggo
+
+import (
+	"go/ast"
+	"slices"
+	"strings"
+)
+
+// gorillaChain gorilla/mux 鏈式路由註冊的解析結果
+// 例如：r.HandleFunc("/users/{id}", h.Get).Methods("GET").Queries("fields", "{fields}")
+type gorillaChain struct {
+	path    string
+	handler ast.Expr
+	methods []string
+	queries []string
+}
+
+// parseGorillaChain 由最外層的呼叫往內拆解 gorilla/mux 的路由鏈，沒有 handler 時回傳 nil
+// 支援兩種寫法：
+//
+//	r.HandleFunc(path, h).Methods("GET")
+//	r.Path(path).Methods("GET").HandlerFunc(h)
+func (p *Parser) parseGorillaChain(call *ast.CallExpr) *gorillaChain {
+	chain := &gorillaChain{}
+
+	var expr ast.Expr = call
+	for {
+		c, ok := expr.(*ast.CallExpr)
+		if !ok {
+			break
+		}
+		sel, ok := c.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return nil
+		}
+
+		switch sel.Sel.Name {
+		case "HandleFunc", "Handle":
+			if len(c.Args) != 2 {
+				return nil
+			}
+			chain.path = p.extractStringArg(c.Args[0])
+			chain.handler = c.Args[1]
+		case "Path", "PathPrefix":
+			if len(c.Args) != 1 {
+				return nil
+			}
+			chain.path = p.extractStringArg(c.Args[0])
+		case "HandlerFunc", "Handler":
+			if len(c.Args) != 1 {
+				return nil
+			}
+			chain.handler = c.Args[0]
+		case "Methods":
+			for _, arg := range c.Args {
+				if method := p.extractHTTPMethodArg(arg); method != "" {
+					chain.methods = append(chain.methods, method)
+				}
+			}
+		case "Queries":
+			// Queries("k1", "{k1}", "k2", "{k2}")，偶數位置是參數名
+			for i := 0; i+1 < len(c.Args); i += 2 {
+				if name := p.extractStringArg(c.Args[i]); name != "" {
+					chain.queries = append(chain.queries, name)
+				}
+			}
+		case "Headers", "HeadersRegexp", "Schemes", "Host", "Name", "MatcherFunc", "BuildVarsFunc":
+		default:
+			return nil
+		}
+
+		expr = sel.X
+	}
+
+	if chain.handler == nil {
+		return nil
+	}
+	return chain
+}
+
+// parseGorillaRouteCall 把 gorilla/mux 的路由鏈轉成 RouteCall，沒有 Methods() 時視為 Any
+func (p *Parser) parseGorillaRouteCall(call *ast.CallExpr) []*RouteCall {
+	chain := p.parseGorillaChain(call)
+	if chain == nil {
+		return nil
+	}
+
+	handler, middlewares := p.unwrapNetHTTPHandler(chain.handler)
+
+	methods := chain.methods
+	if len(methods) == 0 {
+		methods = []string{"Any"}
+	}
+
+	var calls []*RouteCall
+	for _, method := range methods {
+		calls = append(calls, &RouteCall{
+			Method:      method,
+			Path:        chain.path,
+			Handler:     handler,
+			Middlewares: middlewares,
+			Queries:     chain.queries,
+		})
+	}
+	return calls
+}
+
+// isGorillaRouteChainMethod 判斷是否為 gorilla/mux 路由鏈上的方法，用於找出鏈的根 router
+func isGorillaRouteChainMethod(name string) bool {
+	switch name {
+	case "HandleFunc", "Handle", "Path", "PathPrefix", "HandlerFunc", "Handler", "Methods", "Queries",
+		"Headers", "HeadersRegexp", "Schemes", "Host", "Name", "MatcherFunc", "BuildVarsFunc":
+		return true
+	default:
+		return false
+	}
+}
+
+// extractSubrouterCall 解析 api := r.PathPrefix("/api").Subrouter()
+func (p *Parser) extractSubrouterCall(call *ast.CallExpr) *GroupCall {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Subrouter" || len(call.Args) != 0 {
+		return nil
+	}
+
+	inner, ok := sel.X.(*ast.CallExpr)
+	if !ok || len(inner.Args) != 1 {
+		return nil
+	}
+	innerSel, ok := inner.Fun.(*ast.SelectorExpr)
+	if !ok || innerSel.Sel.Name != "PathPrefix" {
+		return nil
+	}
+
+	prefix := p.extractStringArg(inner.Args[0])
+	if prefix == "" {
+		return nil
+	}
+
+	return &GroupCall{Parent: innerSel.X, Prefix: prefix}
+}
+
+// collectMuxVars 記錄 handler 以 mux.Vars(r)["id"] 或 vars := mux.Vars(r); vars["id"] 讀取的 key
+func (p *Parser) collectMuxVars(body *ast.BlockStmt, handler *HandlerInfo) {
+	if body == nil {
+		return
+	}
+
+	varsNames := make(map[string]bool)
+	ast.Inspect(body, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+			return true
+		}
+		if ident, ok := assign.Lhs[0].(*ast.Ident); ok && isMuxVarsCall(assign.Rhs[0]) {
+			varsNames[ident.Name] = true
+		}
+		return true
+	})
+
+	p.inspectHandlerBody(body, handler, func(n ast.Node) bool {
+		index, ok := n.(*ast.IndexExpr)
+		if !ok {
+			return true
+		}
+		ident, isIdent := index.X.(*ast.Ident)
+		if !isMuxVarsCall(index.X) && !(isIdent && varsNames[ident.Name]) {
+			return true
+		}
+
+		if name := p.extractStringArg(index.Index); name != "" && !slices.Contains(handler.muxVars, name) {
+			handler.muxVars = append(handler.muxVars, name)
+		}
+		return true
+	})
+}
+
+// addMuxVarsParams 依路由把 handler 讀取的 mux.Vars key 轉成參數：
+// 出現在 path template 的是 path 參數，由 Queries() 綁定的是 query 參數，其餘忽略
+func (p *Parser) addMuxVarsParams(route *RouteInfo) {
+	if len(route.Handler.muxVars) == 0 {
+		return
+	}
+
+	existing := make(map[string]bool)
+	for _, param := range route.Handler.Parameters {
+		existing[param.In+":"+param.Name] = true
+	}
+
+	for _, name := range route.Handler.muxVars {
+		in := ""
+		switch {
+		case hasPathParam(route.Path, name):
+			in = "path"
+		case slices.Contains(route.QueryParams, name):
+			in = "query"
+		}
+		if in == "" || existing[in+":"+name] {
+			continue
+		}
+		existing[in+":"+name] = true
+		ownRouteHandler(route)
+		route.Handler.Parameters = append(route.Handler.Parameters, &ParameterInfo{
+			Name:     name,
+			Type:     "string",
+			In:       in,
+			Required: true,
+		})
+	}
+}
+
+// hasPathParam 判斷 gin 風格的 path 是否有 :name 或 *name 片段
+func hasPathParam(path, name string) bool {
+	for _, segment := range strings.Split(path, "/") {
+		if segment == ":"+name || segment == "*"+name {
+			return true
+		}
+	}
+	return false
+}
+
+func isMuxVarsCall(expr ast.Expr) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Vars" {
+		return false
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	return ok && ident.Name == "mux"
+}
+
+// getGorillaParamType 檢查參數是否為 *mux.Router
+func getGorillaParamType(expr ast.Expr) string {
+	star, ok := expr.(*ast.StarExpr)
+	if !ok {
+		return ""
+	}
+	sel, ok := star.X.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == "mux" && sel.Sel.Name == "Router" {
+		return sel.Sel.Name
+	}
+	return ""
+}
+
+// gorillaFramework 內建 gorilla/mux adapter，handler 沿用 net/http 簽名
+type gorillaFramework struct{ p *Parser }
+
+func (f *gorillaFramework) Name() string { return FrameworkGorilla }
+
+func (f *gorillaFramework) Match(importPaths []string) bool {
+	return hasImport(importPaths, "github.com/gorilla/mux")
+}
+
+func (f *gorillaFramework) ParseRoute(call *ast.CallExpr) []*RouteCall {
+	return f.p.parseGorillaRouteCall(call)
+}
+
+func (f *gorillaFramework) ParseGroup(call *ast.CallExpr) *GroupCall {
+	return f.p.extractSubrouterCall(call)
+}
+
+func (f *gorillaFramework) RouterParamType(expr ast.Expr) string { return getGorillaParamType(expr) }
+
+func (f *gorillaFramework) IsHandler(fn *ast.FuncType) bool { return f.p.isNetHTTPHandler(fn) }
+
+func (f *gorillaFramework) IsHandlerType(expr ast.Expr) bool { return isNetHTTPHandlerType(expr) }
+
+func (f *gorillaFramework) ContextMethods() map[string]ContextMethod { return nil }
@@ -0,0 +1,213 @@
+package swaggo
+
+import (
+	"testing"
+)
+
+func TestGorillaMuxRoutes(t *testing.T) {
+	src := `package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type User struct {
+	ID   int    ` + "`json:\"id\"`" + `
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type UserHandler struct{}
+
+func (h *UserHandler) Get(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	_ = vars["id"]
+	json.NewEncoder(w).Encode(User{})
+}
+
+func (h *UserHandler) Search(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode([]User{})
+}
+
+func (h *UserHandler) Update(w http.ResponseWriter, r *http.Request) {
+	_ = mux.Vars(r)["id"]
+}
+
+func Health(w http.ResponseWriter, r *http.Request) {}
+
+func main() {
+	h := &UserHandler{}
+
+	r := mux.NewRouter()
+	r.HandleFunc("/health", Health).Methods(http.MethodGet)
+
+	api := r.PathPrefix("/api").Subrouter()
+	api.HandleFunc("/users/{id:[0-9]+}", h.Get).Methods("GET")
+	api.HandleFunc("/users/{id:[0-9]+}", h.Update).Methods("PUT", "PATCH")
+	api.Path("/users").Methods("GET").Queries("q", "{q}").HandlerFunc(h.Search)
+
+	http.ListenAndServe(":8080", r)
+}
+`
+	p := analyzeSource(t, src)
+
+	expected := map[string]string{
+		"GET:/health":          "main.Health",
+		"GET:/api/users/:id":   "main.UserHandler.Get",
+		"PUT:/api/users/:id":   "main.UserHandler.Update",
+		"PATCH:/api/users/:id": "main.UserHandler.Update",
+		"GET:/api/users":       "main.UserHandler.Search",
+	}
+	if len(p.Routes) != len(expected) {
+		for _, route := range p.Routes {
+			t.Logf("  found: %s %s -> %s", route.Method, route.Path, route.HandlerName)
+		}
+		t.Fatalf("expected %d routes, got %d", len(expected), len(p.Routes))
+	}
+	for key, handler := range expected {
+		found := false
+		for _, route := range p.Routes {
+			if route.Method+":"+route.Path == key {
+				found = true
+				if route.HandlerName != handler {
+					t.Errorf("route %s: handler = %q, want %q", key, route.HandlerName, handler)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("expected route %s not found", key)
+		}
+	}
+
+	get := findRoute(p, "GET", "/api/users/:id")
+	if get.PathPatterns["id"] != "^[0-9]+$" {
+		t.Errorf("expected id pattern, got %v", get.PathPatterns)
+	}
+	var pathParams int
+	for _, param := range get.Handler.Parameters {
+		if param.In == "path" && param.Name == "id" {
+			pathParams++
+		}
+	}
+	if pathParams != 1 {
+		t.Errorf("expected exactly one path param id from mux.Vars, got %+v", get.Handler.Parameters)
+	}
+
+	search := findRoute(p, "GET", "/api/users")
+	if search.Handler == nil {
+		t.Fatal("expected handler for GET /api/users")
+	}
+	var hasQuery bool
+	for _, param := range search.Handler.Parameters {
+		if param.In == "query" && param.Name == "q" && param.Required {
+			hasQuery = true
+		}
+	}
+	if !hasQuery {
+		t.Errorf("expected required query param q from Queries(), got %+v", search.Handler.Parameters)
+	}
+}
+
+func TestRouteLevelParamsNotShared(t *testing.T) {
+	p := analyzeSource(t, `package main
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func Search(w http.ResponseWriter, r *http.Request) {
+	_ = r.URL.Query().Get("q")
+}
+
+func main() {
+	r := mux.NewRouter()
+	r.Path("/search").Methods("GET").Queries("q", "{q}").HandlerFunc(Search)
+	r.HandleFunc("/search/{scope}", Search).Methods("GET")
+	r.HandleFunc("/find", Search).Methods("GET")
+}
+`)
+
+	params := func(path string) map[string]*ParameterInfo {
+		route := findRoute(p, "GET", path)
+		if route == nil || route.Handler == nil {
+			t.Fatalf("expected GET %s with handler", path)
+		}
+		byName := make(map[string]*ParameterInfo)
+		for _, param := range route.Handler.Parameters {
+			byName[param.In+":"+param.Name] = param
+		}
+		return byName
+	}
+
+	if q := params("/search")["query:q"]; q == nil || !q.Required {
+		t.Errorf("expected required query q on /search, got %+v", q)
+	}
+	scoped := params("/search/:scope")
+	if q := scoped["query:q"]; q == nil || q.Required {
+		t.Errorf("Queries() of another route leaked into /search/:scope: %+v", q)
+	}
+	if scoped["path:scope"] == nil {
+		t.Errorf("expected path param scope on /search/:scope, got %v", keys(scoped))
+	}
+	find := params("/find")
+	if find["path:scope"] != nil {
+		t.Errorf("path param of another route leaked into /find: %v", keys(find))
+	}
+	if q := find["query:q"]; q == nil || q.Required {
+		t.Errorf("expected optional query q on /find, got %+v", q)
+	}
+}
+
+func TestMuxVarsBoundPerRoute(t *testing.T) {
+	p := analyzeSource(t, `package main
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func Lookup(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	_ = vars["id"]
+	_ = vars["q"]
+}
+
+func main() {
+	r := mux.NewRouter()
+	r.Path("/search").Methods("GET").Queries("q", "{q}").HandlerFunc(Lookup)
+	r.HandleFunc("/items/{id}", Lookup).Methods("GET")
+}
+`)
+
+	params := func(path string) map[string]*ParameterInfo {
+		route := findRoute(p, "GET", path)
+		if route == nil || route.Handler == nil {
+			t.Fatalf("expected GET %s with handler", path)
+		}
+		byName := make(map[string]*ParameterInfo)
+		for _, param := range route.Handler.Parameters {
+			byName[param.In+":"+param.Name] = param
+		}
+		return byName
+	}
+
+	search := params("/search")
+	if q := search["query:q"]; q == nil || !q.Required {
+		t.Errorf("expected required query q on /search, got %v", keys(search))
+	}
+	if search["path:q"] != nil || search["path:id"] != nil {
+		t.Errorf("mux.Vars key outside the path template became a path param on /search: %v", keys(search))
+	}
+	items := params("/items/:id")
+	if items["path:id"] == nil {
+		t.Errorf("expected path param id on /items/:id, got %v", keys(items))
+	}
+	if items["path:q"] != nil || items["query:q"] != nil {
+		t.Errorf("Queries() var of another route leaked into /items/:id: %v", keys(items))
+	}
+}
@@ -0,0 +1,278 @@
+package swaggo
+
+import (
+	"go/ast"
+	"go/token"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// tryBuildInterfaceCallSites 解析透過 interface 呼叫的 registrar，例如：
+//
+//	for _, m := range []Module{users.New(db), orders.New(db)} {
+//		m.Register(api)
+//	}
+//
+// 依 m 可能的具體型別展開成多個呼叫點；無法推斷具體型別時，
+// 以 m 宣告的 interface 型別找出所有實作該 interface 的 registrar
+func (p *Parser) tryBuildInterfaceCallSites(call *ast.CallExpr, file *ast.File, pkgName string, groupPrefixes map[string]string, groupMiddlewares map[string][]*MiddlewareInfo, registrars map[string]*RouteRegistrar) []CallSite {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || len(call.Args) == 0 {
+		return nil
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	// 已知型別的變數與 package 呼叫由 tryBuildCallSite 處理
+	if _, known := p.instanceType(ident); known {
+		return nil
+	}
+
+	concrete, ifaceType := p.receiverTypes(ident, call.Pos(), file, pkgName)
+
+	var matched []*RouteRegistrar
+	for _, typeName := range concrete {
+		if reg, ok := registrars[typeName+"."+sel.Sel.Name]; ok {
+			matched = append(matched, reg)
+		}
+	}
+	if len(matched) == 0 && ifaceType != "" {
+		matched = p.interfaceImplementers(ifaceType, sel.Sel.Name, registrars)
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+
+	groupArg := call.Args[0]
+	sites := make([]CallSite, 0, len(matched))
+	for _, reg := range matched {
+		sites = append(sites, CallSite{
+			Registrar:   reg,
+			GroupPrefix: p.resolveGroupPrefix(groupArg, groupPrefixes),
+			Middlewares: p.resolveGroupMiddlewares(groupArg, groupMiddlewares),
+			groupArg:    groupArg,
+			conditions:  p.guardConditions(call.Pos()),
+		})
+	}
+	return sites
+}
+
+// receiverTypes 推斷變數可能的具體型別，並回傳其宣告的型別（可能是 interface）
+// 來源依序為：所在 range 的 slice 元素、所在函數內的賦值與參數、package 層級的 var 宣告
+func (p *Parser) receiverTypes(ident *ast.Ident, pos token.Pos, file *ast.File, pkgName string) ([]string, string) {
+	var concrete []string
+	var declared string
+	add := func(expr ast.Expr) {
+		if typeName := p.inferControllerType(expr, pkgName); typeName != "" && !slices.Contains(concrete, typeName) {
+			concrete = append(concrete, typeName)
+		}
+	}
+	inScope := func(n ast.Node) bool {
+		return pos >= n.Pos() && pos <= n.End()
+	}
+
+	var scope ast.Node
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if !inScope(d) {
+				continue
+			}
+			scope = d
+			if d.Type.Params != nil {
+				for _, field := range d.Type.Params.List {
+					for _, name := range field.Names {
+						if name.Name == ident.Name {
+							declared = p.typeExprToFullName(field.Type, pkgName)
+						}
+					}
+				}
+			}
+		case *ast.GenDecl:
+			if d.Tok == token.VAR {
+				p.collectValueSpecTypes(d, ident.Name, pkgName, add, &declared)
+			}
+		}
+	}
+	if scope == nil {
+		return concrete, declared
+	}
+
+	ast.Inspect(scope, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.RangeStmt:
+			value, ok := node.Value.(*ast.Ident)
+			if !ok || value.Name != ident.Name || !inScope(node.Body) {
+				return true
+			}
+			elems, elemType := p.sliceElements(node.X, scope, pkgName)
+			for _, elem := range elems {
+				add(elem)
+			}
+			if elemType != "" {
+				declared = elemType
+			}
+
+		case *ast.AssignStmt:
+			if len(node.Lhs) != len(node.Rhs) {
+				return true
+			}
+			for i, lhs := range node.Lhs {
+				if id, ok := lhs.(*ast.Ident); ok && id.Name == ident.Name {
+					add(node.Rhs[i])
+				}
+			}
+
+		case *ast.GenDecl:
+			if node.Tok == token.VAR {
+				p.collectValueSpecTypes(node, ident.Name, pkgName, add, &declared)
+			}
+		}
+		return true
+	})
+
+	return concrete, declared
+}
+
+// collectValueSpecTypes 處理 var m Module = users.New(db) 這類宣告
+func (p *Parser) collectValueSpecTypes(decl *ast.GenDecl, varName, pkgName string, add func(ast.Expr), declared *string) {
+	for _, spec := range decl.Specs {
+		vs, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+		for i, name := range vs.Names {
+			if name.Name != varName {
+				continue
+			}
+			if vs.Type != nil {
+				*declared = p.typeExprToFullName(vs.Type, pkgName)
+			}
+			if i < len(vs.Values) {
+				add(vs.Values[i])
+			}
+		}
+	}
+}
+
+// sliceElements 取得 range 目標的元素：[]Module{a, b}、變數 modules 的 composite 初始值與 append 的值
+// 同時回傳 slice 的元素型別
+func (p *Parser) sliceElements(expr ast.Expr, scope ast.Node, pkgName string) ([]ast.Expr, string) {
+	if cl, ok := expr.(*ast.CompositeLit); ok {
+		elemType := ""
+		if arr, ok := cl.Type.(*ast.ArrayType); ok {
+			elemType = p.typeExprToFullName(arr.Elt, pkgName)
+		}
+		return cl.Elts, elemType
+	}
+
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return nil, ""
+	}
+
+	var elems []ast.Expr
+	var elemType string
+	collect := func(value ast.Expr) {
+		switch v := value.(type) {
+		case *ast.CompositeLit:
+			found, t := p.sliceElements(v, scope, pkgName)
+			elems = append(elems, found...)
+			if t != "" {
+				elemType = t
+			}
+		case *ast.CallExpr:
+			// modules = append(modules, x, y)
+			if fn, ok := v.Fun.(*ast.Ident); ok && fn.Name == "append" && len(v.Args) > 1 {
+				elems = append(elems, v.Args[1:]...)
+			}
+		}
+	}
+
+	ast.Inspect(scope, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range node.Lhs {
+				if id, ok := lhs.(*ast.Ident); ok && id.Name == ident.Name && i < len(node.Rhs) {
+					collect(node.Rhs[i])
+				}
+			}
+		case *ast.ValueSpec:
+			for i, name := range node.Names {
+				if name.Name != ident.Name {
+					continue
+				}
+				if arr, ok := node.Type.(*ast.ArrayType); ok {
+					elemType = p.typeExprToFullName(arr.Elt, pkgName)
+				}
+				if i < len(node.Values) {
+					collect(node.Values[i])
+				}
+			}
+		}
+		return true
+	})
+	return elems, elemType
+}
+
+// interfaceImplementers 找出所有方法集合包含 interface 全部方法的 registrar
+func (p *Parser) interfaceImplementers(ifaceType, method string, registrars map[string]*RouteRegistrar) []*RouteRegistrar {
+	methods := p.interfaceMethods(ifaceType)
+	if len(methods) == 0 || !slices.Contains(methods, method) {
+		return nil
+	}
+
+	var matched []*RouteRegistrar
+	for fullName, reg := range registrars {
+		if reg.Name != method || reg.FuncDecl.Recv == nil {
+			continue
+		}
+		typeName := strings.TrimSuffix(fullName, "."+method)
+		implements := true
+		for _, m := range methods {
+			if _, ok := p.funcDecls[typeName+"."+m]; !ok {
+				implements = false
+				break
+			}
+		}
+		if implements {
+			matched = append(matched, reg)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].FullName < matched[j].FullName })
+	return matched
+}
+
+// interfaceMethods 取得 interface 宣告的方法名稱，找不到時回傳 nil
+func (p *Parser) interfaceMethods(ifaceType string) []string {
+	pkgName, typeName := "", ifaceType
+	if dot := strings.LastIndex(ifaceType, "."); dot >= 0 {
+		pkgName, typeName = ifaceType[:dot], ifaceType[dot+1:]
+	}
+
+	var methods []string
+	for _, file := range p.files {
+		if pkgName != "" && file.Name.Name != pkgName {
+			continue
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			ts, ok := n.(*ast.TypeSpec)
+			if !ok || ts.Name.Name != typeName {
+				return true
+			}
+			iface, ok := ts.Type.(*ast.InterfaceType)
+			if !ok {
+				return false
+			}
+			for _, m := range iface.Methods.List {
+				for _, name := range m.Names {
+					methods = append(methods, name.Name)
+				}
+			}
+			return false
+		})
+	}
+	return methods
+}
@@ -0,0 +1,130 @@
+package swaggo
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+// analyzeFiles 解析多個檔案（可分屬不同 package）並執行 Analyze
+func analyzeFiles(t *testing.T, sources map[string]string) *Parser {
+	t.Helper()
+	p := NewParser()
+	fset := token.NewFileSet()
+	for name, src := range sources {
+		file, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+		if err != nil {
+			t.Fatalf("parse %s: %v", name, err)
+		}
+		p.files = append(p.files, file)
+	}
+	p.fset = fset
+	if err := p.Analyze(); err != nil {
+		t.Fatalf("analyze error: %v", err)
+	}
+	return p
+}
+
+const usersModuleSource = `package users
+
+import "github.com/gin-gonic/gin"
+
+type Module struct{}
+
+func New(db any) *Module { return &Module{} }
+
+func (m *Module) Register(r gin.IRouter) {
+	g := r.Group("/users")
+	g.GET("", m.List)
+}
+
+func (m *Module) List(c *gin.Context) { c.JSON(200, nil) }
+`
+
+const ordersModuleSource = `package orders
+
+import "github.com/gin-gonic/gin"
+
+type Registrar interface {
+	Register(r gin.IRouter)
+}
+
+type module struct{}
+
+func New(db any) Registrar { return &module{} }
+
+func (m *module) Register(r gin.IRouter) {
+	r.POST("/orders", m.Create)
+}
+
+func (m *module) Create(c *gin.Context) { c.JSON(201, nil) }
+`
+
+func TestInterfaceModuleLoop(t *testing.T) {
+	p := analyzeFiles(t, map[string]string{
+		"users/users.go":   usersModuleSource,
+		"orders/orders.go": ordersModuleSource,
+		"main.go": `package main
+
+import (
+	"github.com/gin-gonic/gin"
+	"example.com/app/orders"
+	"example.com/app/users"
+)
+
+type Module interface {
+	Register(r gin.IRouter)
+}
+
+func main() {
+	r := gin.Default()
+	api := r.Group("/api")
+	for _, m := range []Module{users.New(nil), orders.New(nil)} {
+		m.Register(api)
+	}
+}
+`,
+	})
+
+	for _, want := range []struct{ method, path string }{
+		{"GET", "/api/users"},
+		{"POST", "/api/orders"},
+	} {
+		if route := findRoute(p, want.method, want.path); route == nil || route.Handler == nil {
+			for _, route := range p.Routes {
+				t.Logf("  found: %s %s -> %s", route.Method, route.Path, route.HandlerName)
+			}
+			t.Errorf("expected %s %s", want.method, want.path)
+		}
+	}
+}
+
+func TestInterfaceParamImplementers(t *testing.T) {
+	p := analyzeFiles(t, map[string]string{
+		"users/users.go":   usersModuleSource,
+		"orders/orders.go": ordersModuleSource,
+		"main.go": `package main
+
+import (
+	"github.com/gin-gonic/gin"
+	"example.com/app/orders"
+)
+
+func mount(api *gin.RouterGroup, reg orders.Registrar) {
+	reg.Register(api)
+}
+
+func main() {
+	r := gin.Default()
+	mount(r.Group("/v1"), nil)
+}
+`,
+	})
+
+	if route := findRoute(p, "POST", "/orders"); route == nil || route.Handler == nil {
+		for _, route := range p.Routes {
+			t.Logf("  found: %s %s -> %s", route.Method, route.Path, route.HandlerName)
+		}
+		t.Errorf("expected POST /orders from orders.Registrar implementer")
+	}
+}
@@ -0,0 +1,249 @@
+package swaggo
+
+import (
+	"go/ast"
+	"go/token"
+	"slices"
+	"strings"
+)
+
+// instanceScope 函數或區塊內宣告的 controller instance，type 為空字串表示型別未知但遮蔽了外層同名變數
+type instanceScope struct {
+	pos, end token.Pos
+	vars     map[string]string
+}
+
+// extractControllerInstances 依函數與區塊記錄 controller instance 的型別，
+// 例如不同函數內的 h := users.NewHandler() 與 h := orders.NewHandler() 互不覆蓋
+func (p *Parser) extractControllerInstances(file *ast.File) {
+	pkgName := file.Name.Name
+
+	// 先建立作用域，receiver 與參數屬於函數的作用域
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncDecl:
+			scope := p.newInstanceScope(node)
+			p.recordFieldInstances(scope, node.Recv, pkgName)
+			p.recordFieldInstances(scope, node.Type.Params, pkgName)
+		case *ast.FuncLit:
+			scope := p.newInstanceScope(node)
+			p.recordFieldInstances(scope, node.Type.Params, pkgName)
+		case *ast.BlockStmt:
+			p.newInstanceScope(node)
+		}
+		return true
+	})
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.ValueSpec:
+			for i, name := range node.Names {
+				typeName := ""
+				if i < len(node.Values) {
+					typeName = p.inferControllerType(node.Values[i], pkgName)
+				} else if node.Type != nil {
+					typeName = p.methodSetType(node.Type, pkgName)
+				}
+				p.recordInstance(name, typeName)
+			}
+
+		case *ast.AssignStmt:
+			if len(node.Lhs) != len(node.Rhs) {
+				return true
+			}
+			for i, lhs := range node.Lhs {
+				ident, ok := lhs.(*ast.Ident)
+				if !ok || ident.Name == "_" {
+					continue
+				}
+				typeName := p.inferControllerType(node.Rhs[i], pkgName)
+				// = 賦值無法推斷時保留外層已知的型別，:= 則遮蔽外層變數
+				if typeName == "" && node.Tok != token.DEFINE {
+					continue
+				}
+				p.recordInstance(ident, typeName)
+			}
+		}
+		return true
+	})
+}
+
+func (p *Parser) newInstanceScope(node ast.Node) *instanceScope {
+	scope := &instanceScope{pos: node.Pos(), end: node.End(), vars: make(map[string]string)}
+	file := p.fset.File(node.Pos())
+	p.instanceScopes[file] = append(p.instanceScopes[file], scope)
+	return scope
+}
+
+// recordFieldInstances 記錄 receiver 與參數的型別，只保留有方法的型別，略過 *gin.Context 這類外部型別
+func (p *Parser) recordFieldInstances(scope *instanceScope, fields *ast.FieldList, pkgName string) {
+	if fields == nil {
+		return
+	}
+	for _, field := range fields.List {
+		typeName := p.methodSetType(field.Type, pkgName)
+		for _, name := range field.Names {
+			scope.vars[name.Name] = typeName
+		}
+	}
+}
+
+// methodSetType 回傳具有方法宣告的型別完整名稱，其他型別回傳空字串
+func (p *Parser) methodSetType(expr ast.Expr, pkgName string) string {
+	typeName := p.typeExprToFullName(expr, pkgName)
+	if typeName == "" || !p.methodTypes[typeName] {
+		return ""
+	}
+	return typeName
+}
+
+// recordInstance 把變數記錄在最內層的作用域；package 層級或名稱不衝突的變數另外記錄在全域表
+func (p *Parser) recordInstance(ident *ast.Ident, typeName string) {
+	if scope := p.innermostScope(ident.Pos()); scope != nil {
+		scope.vars[ident.Name] = typeName
+	}
+	if typeName == "" || p.ambiguousInstances[ident.Name] {
+		return
+	}
+	if existing, ok := p.controllerInstances[ident.Name]; ok && existing != typeName {
+		delete(p.controllerInstances, ident.Name)
+		p.ambiguousInstances[ident.Name] = true
+		return
+	}
+	p.controllerInstances[ident.Name] = typeName
+}
+
+func (p *Parser) innermostScope(pos token.Pos) *instanceScope {
+	var best *instanceScope
+	for _, scope := range p.instanceScopes[p.fset.File(pos)] {
+		if pos < scope.pos || pos > scope.end {
+			continue
+		}
+		if best == nil || scope.end-scope.pos < best.end-best.pos {
+			best = scope
+		}
+	}
+	return best
+}
+
+// instanceType 依 ident 所在位置由內而外查詢變數的 controller 型別
+func (p *Parser) instanceType(ident *ast.Ident) (string, bool) {
+	var best *instanceScope
+	for _, scope := range p.instanceScopes[p.fset.File(ident.Pos())] {
+		if ident.Pos() < scope.pos || ident.Pos() > scope.end {
+			continue
+		}
+		if _, ok := scope.vars[ident.Name]; !ok {
+			continue
+		}
+		if best == nil || scope.end-scope.pos < best.end-best.pos {
+			best = scope
+		}
+	}
+	if best != nil {
+		typeName := best.vars[ident.Name]
+		return typeName, typeName != ""
+	}
+
+	typeName, ok := p.controllerInstances[ident.Name]
+	return typeName, ok
+}
+
+// inferControllerType 推斷運算式的型別：&Handler{}、Handler{}，
+// 以及由建構函數宣告的回傳型別決定的 NewHandler()、users.New(db)
+func (p *Parser) inferControllerType(expr ast.Expr, currentPkg string) string {
+	return p.inferControllerTypeVisiting(expr, currentPkg, make(map[string]bool))
+}
+
+// inferControllerTypeVisiting visiting 為正在展開的建構函數，避免 NewA 與 NewB 互相回傳時無限遞迴
+func (p *Parser) inferControllerTypeVisiting(expr ast.Expr, currentPkg string, visiting map[string]bool) string {
+	switch e := expr.(type) {
+	case *ast.UnaryExpr:
+		if e.Op == token.AND {
+			return p.inferControllerTypeVisiting(e.X, currentPkg, visiting)
+		}
+	case *ast.CompositeLit:
+		return p.typeExprToFullName(e.Type, currentPkg)
+	case *ast.CallExpr:
+		if fullName, decl := p.constructorDecl(e, currentPkg); decl != nil {
+			if visiting[fullName] {
+				return ""
+			}
+			visiting[fullName] = true
+			defer delete(visiting, fullName)
+			return p.constructorResultType(fullName, decl, visiting)
+		}
+		// 建構函數不在解析範圍內（例如外部套件）時，沿用 NewXxx → Xxx 的命名慣例
+		var pkgName, funcName string
+		switch fn := e.Fun.(type) {
+		case *ast.SelectorExpr:
+			if pkgIdent, ok := fn.X.(*ast.Ident); ok {
+				pkgName, funcName = pkgIdent.Name, fn.Sel.Name
+			}
+		case *ast.Ident:
+			pkgName, funcName = currentPkg, fn.Name
+		}
+		if typeName := strings.TrimPrefix(funcName, "New"); typeName != funcName && typeName != "" {
+			return pkgName + "." + typeName
+		}
+	}
+	return ""
+}
+
+// constructorDecl 找出 NewHandler() 或 users.New(db) 對應的函數宣告（不含 method）
+func (p *Parser) constructorDecl(call *ast.CallExpr, currentPkg string) (string, *ast.FuncDecl) {
+	var fullName string
+	switch fn := call.Fun.(type) {
+	case *ast.Ident:
+		fullName = currentPkg + "." + fn.Name
+	case *ast.SelectorExpr:
+		pkgIdent, ok := fn.X.(*ast.Ident)
+		if !ok {
+			return "", nil
+		}
+		fullName = pkgIdent.Name + "." + fn.Sel.Name
+	default:
+		return "", nil
+	}
+
+	decl, ok := p.funcDecls[fullName]
+	if !ok || decl.Recv != nil {
+		return "", nil
+	}
+	return fullName, decl
+}
+
+// constructorResultType 以宣告的回傳型別決定建構函數的結果；
+// 回傳 interface 時，若所有 return 都是同一個具體型別則以該型別為準
+func (p *Parser) constructorResultType(fullName string, decl *ast.FuncDecl, visiting map[string]bool) string {
+	if decl.Type.Results == nil || len(decl.Type.Results.List) == 0 {
+		return ""
+	}
+	declPkg := strings.SplitN(fullName, ".", 2)[0]
+	typeName := p.typeExprToFullName(decl.Type.Results.List[0].Type, declPkg)
+	if typeName == "" || decl.Body == nil || len(p.interfaceMethods(typeName)) == 0 {
+		return typeName
+	}
+
+	var concrete []string
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(node.Results) == 0 {
+				return false
+			}
+			if t := p.inferControllerTypeVisiting(node.Results[0], declPkg, visiting); t != "" && !slices.Contains(concrete, t) {
+				concrete = append(concrete, t)
+			}
+			return false
+		}
+		return true
+	})
+
+	if len(concrete) == 1 {
+		return concrete[0]
+	}
+	return typeName
+}
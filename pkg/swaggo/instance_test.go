@@ -0,0 +1,98 @@
+package swaggo
+
+import "testing"
+
+func TestScopedControllerInstances(t *testing.T) {
+	p := analyzeFiles(t, map[string]string{
+		"users/handler.go": `package users
+
+import "github.com/gin-gonic/gin"
+
+type Handler struct{}
+
+func NewHandler() *Handler { return &Handler{} }
+
+func (h *Handler) List(c *gin.Context) { c.JSON(200, []string{}) }
+`,
+		"orders/handler.go": `package orders
+
+import "github.com/gin-gonic/gin"
+
+type Service interface {
+	List(c *gin.Context)
+}
+
+type handler struct{}
+
+func NewHandler() Service { return &handler{} }
+
+func (h *handler) List(c *gin.Context) { c.JSON(200, []int{}) }
+`,
+		"main.go": `package main
+
+import (
+	"github.com/gin-gonic/gin"
+	"example.com/app/orders"
+	"example.com/app/users"
+)
+
+func registerUsers(r *gin.Engine) {
+	h := users.NewHandler()
+	r.GET("/users", h.List)
+}
+
+func registerOrders(r *gin.Engine) {
+	h := orders.NewHandler()
+	r.GET("/orders", h.List)
+}
+
+func main() {
+	r := gin.Default()
+	registerUsers(r)
+	registerOrders(r)
+}
+`,
+	})
+
+	for path, want := range map[string]string{
+		"/users":  "users.Handler.List",
+		"/orders": "orders.handler.List",
+	} {
+		route := findRoute(p, "GET", path)
+		if route == nil {
+			t.Errorf("expected GET %s", path)
+			continue
+		}
+		if route.HandlerName != want {
+			t.Errorf("GET %s handler = %q, want %q", path, route.HandlerName, want)
+		}
+	}
+}
+
+func TestMutuallyRecursiveConstructors(t *testing.T) {
+	p := analyzeSource(t, `package main
+
+import "github.com/gin-gonic/gin"
+
+type Service interface {
+	List(c *gin.Context)
+}
+
+func NewA(loop bool) Service {
+	return NewB(loop)
+}
+
+func NewB(loop bool) Service {
+	return NewA(loop)
+}
+
+func main() {
+	r := gin.Default()
+	h := NewA(true)
+	r.GET("/items", h.List)
+}
+`)
+	if route := findRoute(p, "GET", "/items"); route == nil {
+		t.Error("expected GET /items")
+	}
+}
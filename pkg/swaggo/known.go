@@ -0,0 +1,95 @@
+package swaggo
+
+import "strings"
+
+// knownHandler 常見第三方 net/http handler 的文件描述
+type knownHandler struct {
+	summary     string
+	tag         string
+	status      int
+	contentType string
+}
+
+// knownHandlers 以 package.Function 索引的第三方 handler，通常透過 gin.WrapH / WrapF 或 mux.Handle 掛載
+var knownHandlers = map[string]knownHandler{
+	"promhttp.Handler":                 {"Prometheus metrics", "metrics", 200, "text/plain"},
+	"promhttp.HandlerFor":              {"Prometheus metrics", "metrics", 200, "text/plain"},
+	"promhttp.InstrumentMetricHandler": {"Prometheus metrics", "metrics", 200, "text/plain"},
+	"expvar.Handler":                   {"Exported variables", "debug", 200, "application/json"},
+	"pprof.Index":                      {"pprof index", "debug", 200, "text/html"},
+	"pprof.Cmdline":                    {"pprof command line", "debug", 200, "text/plain"},
+	"pprof.Profile":                    {"pprof CPU profile", "debug", 200, "application/octet-stream"},
+	"pprof.Symbol":                     {"pprof symbol lookup", "debug", 200, "text/plain"},
+	"pprof.Trace":                      {"pprof execution trace", "debug", 200, "application/octet-stream"},
+	"pprof.Handler":                    {"pprof profile", "debug", 200, "application/octet-stream"},
+	"http.FileServer":                  {"Static files", "", 200, "application/octet-stream"},
+	"http.NotFoundHandler":             {"Not found", "", 404, "text/plain"},
+	"httpSwagger.WrapHandler":          {"Swagger UI", "docs", 200, "text/html"},
+	"httpSwagger.Handler":              {"Swagger UI", "docs", 200, "text/html"},
+	"ginSwagger.WrapHandler":           {"Swagger UI", "docs", 200, "text/html"},
+	"echoSwagger.WrapHandler":          {"Swagger UI", "docs", 200, "text/html"},
+}
+
+// isThirdPartyHandler 有 tag 的已知 handler（metrics、debug、docs）是第三方工具路由，與 registrar 一樣受 thirdPartyMode 控制；
+// http.FileServer 等提供應用本身內容的 handler 不受影響
+func isThirdPartyHandler(name string) bool {
+	known, ok := knownHandlers[name]
+	return ok && known.tag != ""
+}
+
+// excludeThirdPartyHandlerRoutes 在 ThirdPartyExclude 模式下移除掛載已知第三方 handler 的路由
+func (p *Parser) excludeThirdPartyHandlerRoutes() {
+	if p.thirdPartyMode != ThirdPartyExclude {
+		return
+	}
+	kept := p.Routes[:0]
+	for _, route := range p.Routes {
+		if _, declared := p.Handlers[route.HandlerName]; !declared && isThirdPartyHandler(route.HandlerName) {
+			continue
+		}
+		kept = append(kept, route)
+	}
+	p.Routes = kept
+}
+
+// knownHandlerInfo 為已知的第三方 handler 建立 HandlerInfo，不認得時回傳 nil
+func (p *Parser) knownHandlerInfo(name string) *HandlerInfo {
+	known, ok := knownHandlers[name]
+	if !ok {
+		return nil
+	}
+
+	handler := &HandlerInfo{
+		Name:      p.getSimpleName(name),
+		FullName:  name,
+		Receiver:  strings.TrimSuffix(name, "."+p.getSimpleName(name)),
+		Summary:   known.summary,
+		Responses: make(map[int]*ResponseInfo),
+	}
+	if known.tag != "" {
+		handler.Tags = []string{known.tag}
+		if p.thirdPartyMode == ThirdPartyInternal {
+			handler.Tags = []string{"internal"}
+		}
+	}
+
+	handler.Responses[known.status] = &ResponseInfo{
+		StatusCode:  known.status,
+		ContentType: known.contentType,
+		Type:        contentTypeSchema(known.contentType),
+	}
+	return handler
+}
+
+// contentTypeSchema 依第三方回應的 content type 決定回應型別：JSON（空字串亦同）為 object、
+// application/octet-stream 為 binary，其餘為字串
+func contentTypeSchema(contentType string) *TypeInfo {
+	switch contentType {
+	case "", "application/json":
+		return &TypeInfo{Kind: "map", Name: "object"}
+	case "application/octet-stream":
+		return &TypeInfo{Kind: "primitive", Name: "binary"}
+	default:
+		return &TypeInfo{Kind: "primitive", Name: "string"}
+	}
+}
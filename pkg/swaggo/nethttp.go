@@ -0,0 +1,305 @@
+package swaggo
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// parseNetHTTPRouteCall 解析 http.ServeMux 的路由註冊（Go 1.22 pattern）
+//
+//	mux.HandleFunc("GET /users/{id}", h.get)
+//	mux.Handle("POST /users", auth(http.HandlerFunc(h.create)))
+func (p *Parser) parseNetHTTPRouteCall(call *ast.CallExpr, method string) []*RouteCall {
+	if method != "HandleFunc" && method != "Handle" {
+		return nil
+	}
+	if len(call.Args) != 2 {
+		return nil
+	}
+
+	pattern := p.extractStringArg(call.Args[0])
+	if pattern == "" {
+		return nil
+	}
+
+	// 掛載子 mux 的 StripPrefix 不是路由本身，由 collectMountPrefixes 處理
+	if isStripPrefixCall(call.Args[1]) {
+		return nil
+	}
+
+	httpMethod, path := parseServeMuxPattern(pattern)
+	handler, middlewares := p.unwrapNetHTTPHandler(call.Args[1])
+
+	return []*RouteCall{{
+		Method:      httpMethod,
+		Path:        path,
+		Handler:     handler,
+		Middlewares: middlewares,
+	}}
+}
+
+// parseServeMuxPattern 拆解 "[METHOD ][HOST]/path" pattern，並把 wildcard 轉成 gin 風格
+// 例如："GET /users/{id}" → ("GET", "/users/:id")；沒有 method 時視為 Any
+func parseServeMuxPattern(pattern string) (string, string) {
+	method := "Any"
+	pattern = strings.TrimSpace(pattern)
+	if i := strings.IndexAny(pattern, " \t"); i >= 0 {
+		method = strings.ToUpper(pattern[:i])
+		pattern = strings.TrimSpace(pattern[i+1:])
+	}
+
+	// 去掉 host 部分
+	if i := strings.Index(pattern, "/"); i > 0 {
+		pattern = pattern[i:]
+	}
+
+	path, _ := convertBracePath(pattern)
+	return method, path
+}
+
+// unwrapNetHTTPHandler 拆開 middleware 包裝，例如 auth(logging(http.HandlerFunc(h.get)))
+// 回傳最內層的 handler 與由外而內的 middleware
+func (p *Parser) unwrapNetHTTPHandler(expr ast.Expr) (ast.Expr, []ast.Expr) {
+	var middlewares []ast.Expr
+
+	for {
+		call, ok := expr.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return expr, middlewares
+		}
+
+		// http.HandlerFunc(fn) 只是型別轉換
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "HandlerFunc" {
+			if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == "http" {
+				expr = call.Args[0]
+				continue
+			}
+		}
+
+		// handler 工廠（不接收 handler 參數的函數）不再往內拆
+		if !p.isNetHTTPMiddlewareCall(call) {
+			return expr, middlewares
+		}
+
+		inner := call.Args[len(call.Args)-1]
+		switch inner.(type) {
+		case *ast.Ident, *ast.SelectorExpr, *ast.CallExpr, *ast.FuncLit:
+		default:
+			return expr, middlewares
+		}
+
+		middlewares = append(middlewares, call)
+		expr = inner
+	}
+}
+
+// isNetHTTPMiddlewareCall 判斷呼叫是否為 func(next http.Handler) http.Handler 形式的包裝
+// 已知的第三方 handler（例如 http.FileServer）不是 middleware，其餘找不到宣告（外部套件）時視為 middleware
+func (p *Parser) isNetHTTPMiddlewareCall(call *ast.CallExpr) bool {
+	name := p.resolveHandlerName(call, "")
+	if name == "" {
+		return false
+	}
+	if _, ok := knownHandlers[name]; ok {
+		return false
+	}
+	fn := p.findFuncDecl(name)
+	if fn == nil {
+		return true
+	}
+	for _, param := range fn.Type.Params.List {
+		if isNetHTTPHandlerType(param.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// usesServeMux 判斷檔案是否實際使用 http.ServeMux，例如 http.NewServeMux()、*http.ServeMux 或 http.HandleFunc
+func usesServeMux(file *ast.File) bool {
+	found := false
+	ast.Inspect(file, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == "http" {
+			switch sel.Sel.Name {
+			case "ServeMux", "NewServeMux", "DefaultServeMux", "HandleFunc", "Handle":
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+func isStripPrefixCall(expr ast.Expr) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == "StripPrefix"
+}
+
+// getNetHTTPParamType 檢查參數是否為 *http.ServeMux
+func getNetHTTPParamType(expr ast.Expr) string {
+	star, ok := expr.(*ast.StarExpr)
+	if !ok {
+		return ""
+	}
+	sel, ok := star.X.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == "http" && sel.Sel.Name == "ServeMux" {
+		return sel.Sel.Name
+	}
+	return ""
+}
+
+// isNetHTTPHandler 判斷函數簽名是否為 func(w http.ResponseWriter, r *http.Request)
+func (p *Parser) isNetHTTPHandler(fn *ast.FuncType) bool {
+	if fn.Params == nil {
+		return false
+	}
+	for _, param := range fn.Params.List {
+		if p.typeToString(param.Type) == "http.ResponseWriter" {
+			return true
+		}
+	}
+	return false
+}
+
+// isNetHTTPHandlerType 判斷型別是否為 http.HandlerFunc 或 http.Handler
+func isNetHTTPHandlerType(expr ast.Expr) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	return ok && ident.Name == "http" && (sel.Sel.Name == "HandlerFunc" || sel.Sel.Name == "Handler")
+}
+
+// analyzeNetHTTPResponses 分析 w.WriteHeader / json.NewEncoder(w).Encode / http.Error
+// 依區塊追蹤目前的 status code，Encode 時沒有設定過 status 則視為 200
+func (p *Parser) analyzeNetHTTPResponses(body *ast.BlockStmt, handler *HandlerInfo, localVarTypes map[string]string) {
+	if body == nil {
+		return
+	}
+	p.analyzeNetHTTPStmts(body.List, 0, handler, localVarTypes)
+}
+
+func (p *Parser) analyzeNetHTTPStmts(stmts []ast.Stmt, status int, handler *HandlerInfo, localVarTypes map[string]string) {
+	for _, stmt := range stmts {
+		p.inspectHandlerBody(stmt, handler, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.BlockStmt:
+				p.analyzeNetHTTPStmts(node.List, status, handler, localVarTypes)
+				return false
+			case *ast.FuncLit:
+				return false
+			case *ast.CallExpr:
+				sel, ok := node.Fun.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+				switch sel.Sel.Name {
+				case "WriteHeader":
+					if len(node.Args) == 1 {
+						if code := p.extractStatusCode(node.Args[0]); code > 0 {
+							status = code
+							if _, exists := handler.Responses[code]; !exists {
+								handler.Responses[code] = &ResponseInfo{StatusCode: code}
+							}
+						}
+					}
+				case "Encode":
+					if !isJSONEncoderCall(sel.X) || len(node.Args) == 0 {
+						return true
+					}
+					code := status
+					if code == 0 {
+						code = 200
+					}
+					resp := &ResponseInfo{StatusCode: code}
+					resp.Type, resp.IsArray = p.extractResponseTypeWithLocals(node.Args[0], localVarTypes)
+					handler.Responses[code] = resp
+				case "Error":
+					if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == "http" && len(node.Args) == 3 {
+						if code := p.extractStatusCode(node.Args[2]); code > 0 {
+							handler.Responses[code] = &ResponseInfo{
+								StatusCode: code,
+								Type:       &TypeInfo{Kind: "primitive", Name: "string"},
+							}
+						}
+					}
+				}
+			}
+			return true
+		})
+	}
+}
+
+// isJSONEncoderCall 判斷 expr 是否為 json.NewEncoder(w)
+func isJSONEncoderCall(expr ast.Expr) bool {
+	return isJSONCall(expr, "NewEncoder")
+}
+
+// isJSONDecoderCall 判斷 expr 是否為 json.NewDecoder(r.Body)
+func isJSONDecoderCall(expr ast.Expr) bool {
+	return isJSONCall(expr, "NewDecoder")
+}
+
+func isJSONCall(expr ast.Expr, name string) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != name {
+		return false
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	return ok && ident.Name == "json"
+}
+
+// isURLQueryGet 判斷 selector 是否為 r.URL.Query().Get
+func isURLQueryGet(sel *ast.SelectorExpr) bool {
+	if sel.Sel.Name != "Get" {
+		return false
+	}
+	call, ok := sel.X.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	inner, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && inner.Sel.Name == "Query"
+}
+
+// netHTTPFramework 內建標準庫 http.ServeMux adapter
+type netHTTPFramework struct{ p *Parser }
+
+func (f *netHTTPFramework) Name() string { return FrameworkNetHTTP }
+
+func (f *netHTTPFramework) Match(importPaths []string) bool {
+	return hasImport(importPaths, "net/http")
+}
+
+func (f *netHTTPFramework) ParseRoute(call *ast.CallExpr) []*RouteCall {
+	return f.p.parseNetHTTPRouteCall(call, call.Fun.(*ast.SelectorExpr).Sel.Name)
+}
+
+func (f *netHTTPFramework) ParseGroup(call *ast.CallExpr) *GroupCall { return nil }
+
+func (f *netHTTPFramework) RouterParamType(expr ast.Expr) string { return getNetHTTPParamType(expr) }
+
+func (f *netHTTPFramework) IsHandler(fn *ast.FuncType) bool { return f.p.isNetHTTPHandler(fn) }
+
+func (f *netHTTPFramework) IsHandlerType(expr ast.Expr) bool { return isNetHTTPHandlerType(expr) }
+
+func (f *netHTTPFramework) ContextMethods() map[string]ContextMethod { return nil }
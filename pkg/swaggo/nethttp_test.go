@@ -0,0 +1,210 @@
+package swaggo
+
+import (
+	"testing"
+)
+
+func TestNetHTTPServeMux(t *testing.T) {
+	src := `package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type CreateUserRequest struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type User struct {
+	ID   int    ` + "`json:\"id\"`" + `
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type UserHandler struct{}
+
+// get 取得使用者
+func (h *UserHandler) get(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	_ = r.URL.Query().Get("fields")
+	if id == "" {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	json.NewEncoder(w).Encode(User{})
+}
+
+func (h *UserHandler) create(w http.ResponseWriter, r *http.Request) {
+	var req CreateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(User{})
+}
+
+func (h *UserHandler) files(w http.ResponseWriter, r *http.Request) {}
+
+func auth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func main() {
+	h := &UserHandler{}
+
+	api := http.NewServeMux()
+	api.HandleFunc("GET /users/{id}", h.get)
+	api.Handle("POST /users", auth(http.HandlerFunc(h.create)))
+
+	mux := http.NewServeMux()
+	mux.Handle("/api/", http.StripPrefix("/api", api))
+	mux.HandleFunc("GET /files/{path...}", h.files)
+
+	http.ListenAndServe(":8080", mux)
+}
+`
+	p := analyzeSource(t, src)
+
+	expected := map[string]string{
+		"GET:/api/users/:id": "main.UserHandler.get",
+		"POST:/api/users":    "main.UserHandler.create",
+		"GET:/files/*path":   "main.UserHandler.files",
+	}
+	if len(p.Routes) != len(expected) {
+		for _, route := range p.Routes {
+			t.Logf("  found: %s %s -> %s", route.Method, route.Path, route.HandlerName)
+		}
+		t.Fatalf("expected %d routes, got %d", len(expected), len(p.Routes))
+	}
+	for key, handler := range expected {
+		found := false
+		for _, route := range p.Routes {
+			if route.Method+":"+route.Path == key {
+				found = true
+				if route.HandlerName != handler {
+					t.Errorf("route %s: handler = %q, want %q", key, route.HandlerName, handler)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("expected route %s not found", key)
+		}
+	}
+
+	create := findRoute(p, "POST", "/api/users")
+	if len(create.Middlewares) != 1 || create.Middlewares[0].Name != "main.auth" {
+		t.Errorf("expected auth middleware, got %+v", create.Middlewares)
+	}
+	if len(create.Security) != 1 || create.Security[0].Scheme != "bearer" {
+		t.Errorf("expected bearer security, got %+v", create.Security)
+	}
+	if create.Handler == nil || create.Handler.RequestBody == nil || create.Handler.RequestBody.Name != "CreateUserRequest" {
+		t.Fatalf("expected request body CreateUserRequest, got %+v", create.Handler)
+	}
+	if resp := create.Handler.Responses[201]; resp == nil || resp.Type == nil || resp.Type.Name != "User" {
+		t.Errorf("expected 201 response of User, got %+v", create.Handler.Responses)
+	}
+	if resp := create.Handler.Responses[400]; resp == nil || resp.Type != nil {
+		t.Errorf("expected empty 400 response, got %+v", create.Handler.Responses)
+	}
+	if _, ok := create.Handler.Responses[200]; ok {
+		t.Error("status from WriteHeader should not fall back to 200")
+	}
+
+	gen := New()
+	gen.parser = p
+	spec, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("generate error: %v", err)
+	}
+
+	get := spec.Paths["/api/users/{id}"].Get
+	if get == nil {
+		t.Fatal("expected GET /api/users/{id}")
+	}
+	params := make(map[string]string)
+	for _, param := range get.Parameters {
+		params[param.Name] = param.In
+	}
+	if params["id"] != "path" || params["fields"] != "query" {
+		t.Errorf("expected path id and query fields, got %+v", params)
+	}
+	if _, ok := get.Responses["200"]; !ok {
+		t.Errorf("expected 200 response, got %v", get.Responses)
+	}
+	if _, ok := get.Responses["404"]; !ok {
+		t.Errorf("expected 404 response from http.Error, got %v", get.Responses)
+	}
+
+	if _, ok := spec.Paths["/files/{path"]; ok {
+		t.Error("catch-all wildcard rendered as a malformed path key")
+	}
+	files := spec.Paths["/files/{path}"].Get
+	if files == nil {
+		t.Fatalf("expected GET /files/{path}, got paths %v", keys(spec.Paths))
+	}
+	if len(files.Parameters) != 1 || files.Parameters[0].Name != "path" || files.Parameters[0].In != "path" {
+		t.Errorf("expected path parameter path, got %+v", files.Parameters)
+	}
+}
+
+func TestParseServeMuxPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		method  string
+		path    string
+	}{
+		{"GET /users/{id}", "GET", "/users/:id"},
+		{"POST example.com/items", "POST", "/items"},
+		{"/static/{file...}", "Any", "/static/*file"},
+		{"GET /{$}", "GET", "/"},
+	}
+
+	for _, tt := range tests {
+		method, path := parseServeMuxPattern(tt.pattern)
+		if method != tt.method || path != tt.path {
+			t.Errorf("parseServeMuxPattern(%q) = (%q, %q), want (%q, %q)", tt.pattern, method, path, tt.method, tt.path)
+		}
+	}
+}
+
+func TestNetHTTPImportKeepsGinRouter(t *testing.T) {
+	p := analyzeFiles(t, map[string]string{
+		"server/router.go": `package server
+
+import "github.com/gin-gonic/gin"
+
+func NewRouter() *gin.Engine { return gin.New() }
+`,
+		"main.go": `package main
+
+import (
+	"net/http"
+
+	"example.com/app/server"
+)
+
+func main() {
+	r := server.NewRouter()
+	r.GET("/users", ListUsers)
+	http.ListenAndServe(":8080", r)
+}
+`,
+		"handlers.go": `package main
+
+import "github.com/gin-gonic/gin"
+
+func ListUsers(c *gin.Context) { c.JSON(200, nil) }
+`,
+	})
+	if route := findRoute(p, "GET", "/users"); route == nil {
+		t.Fatal("expected GET /users from a file importing only net/http and the router package")
+	}
+}
@@ -55,6 +55,50 @@ type PathItem struct {
 	Head    *Operation `json:"head,omitempty" yaml:"head,omitempty"`
 }
 
+// operation 回傳 method 對應的 operation，method 需為大寫
+func (item *PathItem) operation(method string) *Operation {
+	switch method {
+	case "GET":
+		return item.Get
+	case "POST":
+		return item.Post
+	case "PUT":
+		return item.Put
+	case "DELETE":
+		return item.Delete
+	case "PATCH":
+		return item.Patch
+	case "OPTIONS":
+		return item.Options
+	case "HEAD":
+		return item.Head
+	}
+	return nil
+}
+
+// setOperation 設定 method 對應的 operation，method 不支援時回傳 false
+func (item *PathItem) setOperation(method string, op *Operation) bool {
+	switch method {
+	case "GET":
+		item.Get = op
+	case "POST":
+		item.Post = op
+	case "PUT":
+		item.Put = op
+	case "DELETE":
+		item.Delete = op
+	case "PATCH":
+		item.Patch = op
+	case "OPTIONS":
+		item.Options = op
+	case "HEAD":
+		item.Head = op
+	default:
+		return false
+	}
+	return true
+}
+
 type Operation struct {
 	Tags        []string              `json:"tags,omitempty" yaml:"tags,omitempty"`
 	Summary     string                `json:"summary,omitempty" yaml:"summary,omitempty"`
@@ -65,6 +109,10 @@ type Operation struct {
 	Responses   map[string]Response   `json:"responses" yaml:"responses"`
 	Deprecated  bool                  `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
 	Security    []SecurityRequirement `json:"security,omitempty" yaml:"security,omitempty"`
+	XRoles      []string              `json:"x-roles,omitempty" yaml:"x-roles,omitempty"`
+	XScopes     []string              `json:"x-scopes,omitempty" yaml:"x-scopes,omitempty"`
+	XCondition  string                `json:"x-condition,omitempty" yaml:"x-condition,omitempty"`
+	XSource     string                `json:"x-source,omitempty" yaml:"x-source,omitempty"`
 }
 
 type Parameter struct {
@@ -74,18 +122,21 @@ type Parameter struct {
 	Required    bool    `json:"required,omitempty" yaml:"required,omitempty"`
 	Schema      *Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
 	Example     any     `json:"example,omitempty" yaml:"example,omitempty"`
+	XSource     string  `json:"x-source,omitempty" yaml:"x-source,omitempty"`
 }
 
 type RequestBody struct {
 	Description string               `json:"description,omitempty" yaml:"description,omitempty"`
 	Content     map[string]MediaType `json:"content" yaml:"content"`
 	Required    bool                 `json:"required,omitempty" yaml:"required,omitempty"`
+	XSource     string               `json:"x-source,omitempty" yaml:"x-source,omitempty"`
 }
 
 type Response struct {
 	Description string               `json:"description" yaml:"description"`
 	Content     map[string]MediaType `json:"content,omitempty" yaml:"content,omitempty"`
 	Headers     map[string]Header    `json:"headers,omitempty" yaml:"headers,omitempty"`
+	XSource     string               `json:"x-source,omitempty" yaml:"x-source,omitempty"`
 }
 
 type MediaType struct {
@@ -109,6 +160,7 @@ type Schema struct {
 	Ref         string             `json:"$ref,omitempty" yaml:"$ref,omitempty"`
 	Example     any                `json:"example,omitempty" yaml:"example,omitempty"`
 	Nullable    bool               `json:"nullable,omitempty" yaml:"nullable,omitempty"`
+	Pattern     string             `json:"pattern,omitempty" yaml:"pattern,omitempty"`
 }
 
 type Components struct {
@@ -117,12 +169,27 @@ type Components struct {
 }
 
 type SecurityScheme struct {
-	Type         string `json:"type" yaml:"type"`
-	Description  string `json:"description,omitempty" yaml:"description,omitempty"`
-	Name         string `json:"name,omitempty" yaml:"name,omitempty"`
-	In           string `json:"in,omitempty" yaml:"in,omitempty"`
-	Scheme       string `json:"scheme,omitempty" yaml:"scheme,omitempty"`
-	BearerFormat string `json:"bearerFormat,omitempty" yaml:"bearerFormat,omitempty"`
+	Type         string      `json:"type" yaml:"type"`
+	Description  string      `json:"description,omitempty" yaml:"description,omitempty"`
+	Name         string      `json:"name,omitempty" yaml:"name,omitempty"`
+	In           string      `json:"in,omitempty" yaml:"in,omitempty"`
+	Scheme       string      `json:"scheme,omitempty" yaml:"scheme,omitempty"`
+	BearerFormat string      `json:"bearerFormat,omitempty" yaml:"bearerFormat,omitempty"`
+	Flows        *OAuthFlows `json:"flows,omitempty" yaml:"flows,omitempty"`
+}
+
+type OAuthFlows struct {
+	Implicit          *OAuthFlow `json:"implicit,omitempty" yaml:"implicit,omitempty"`
+	Password          *OAuthFlow `json:"password,omitempty" yaml:"password,omitempty"`
+	ClientCredentials *OAuthFlow `json:"clientCredentials,omitempty" yaml:"clientCredentials,omitempty"`
+	AuthorizationCode *OAuthFlow `json:"authorizationCode,omitempty" yaml:"authorizationCode,omitempty"`
+}
+
+type OAuthFlow struct {
+	AuthorizationURL string            `json:"authorizationUrl,omitempty" yaml:"authorizationUrl,omitempty"`
+	TokenURL         string            `json:"tokenUrl,omitempty" yaml:"tokenUrl,omitempty"`
+	RefreshURL       string            `json:"refreshUrl,omitempty" yaml:"refreshUrl,omitempty"`
+	Scopes           map[string]string `json:"scopes" yaml:"scopes"`
 }
 
 type SecurityRequirement map[string][]string
@@ -2,6 +2,7 @@ package swaggo
 
 import (
 	"go/ast"
+	"go/build"
 	"go/parser"
 	"go/token"
 	"os"
@@ -13,18 +14,41 @@ import (
 // Parser 解析 Go 原始碼，提取 API 資訊
 type Parser struct {
 	fset     *token.FileSet
-	packages map[string]*ast.Package
+	packages map[string]*ast.Package // 以 import path 索引
 	files    []*ast.File
 
 	Routes   []*RouteInfo
 	Handlers map[string]*HandlerInfo
 	Types    map[string]*TypeInfo
 
-	controllerInstances map[string]string
-	routeRegistrars     map[string]*RouteRegistrar // 記錄接受 RouterGroup 參數的函數
-	excludeDirs         []string
-	parseVendor         bool
-	parseDependency     bool
+	Diagnostics []Diagnostic
+	ParseErrors ParseErrors // 語法錯誤，有錯誤的檔案不會被解析
+
+	controllerInstances  map[string]string                // package 層級與名稱不衝突的 instance，作用域查不到時使用
+	instanceScopes       map[*token.File][]*instanceScope // 以檔案索引的作用域
+	ambiguousInstances   map[string]bool
+	routeRegistrars      map[string]*RouteRegistrar // 記錄接受 RouterGroup 參數的函數
+	funcDecls            map[string]*ast.FuncDecl   // 以 FullName 索引的函數宣告
+	methodTypes          map[string]bool            // 有方法宣告的型別完整名稱，例如 users.Handler
+	authMiddlewares      map[string]*SecurityInfo   // middleware 名稱 → 認證方式（nil 表示非認證）
+	authorizationRules   []AuthorizationRule
+	excludeDirs          []string
+	parseVendor          bool
+	parseDependency      bool
+	pruneUnreachable     bool                     // 移除無法由 main 到達的路由註冊
+	oauth2TokenURL       string                   // 未設定時 scope 只輸出為 x-scopes
+	entryFile            string                   // ParseFromEntry 的入口檔案（絕對路徑）
+	typedAdapters        map[string]*ast.FuncDecl // 泛型 handler adapter，例如 Typed[Req, Resp]
+	closureFactories     map[string]*ClosureFactory
+	typeSubst            map[string]string // 分析特化的工廠時，型別參數 → 實際型別
+	customFrameworks     []Framework
+	thirdPartyRegistrars []ThirdPartyRegistrar
+	thirdPartyMode       string
+	frameworks           []Framework   // 自訂框架在前，內建框架在後
+	localModules         []*goModule   // 專案內的模組：go.work 的 use、巢狀 go.mod 與 replace 指向的本地目錄
+	buildContext         build.Context // 評估 build constraint 的 GOOS/GOARCH 與 build tag
+	includeConditions    []string      // 有條件的路由只保留符合的，例如 cfg.Features.*
+	excludeConditions    []string      // 排除條件符合的路由，例如 gin.Mode() == gin.DebugMode
 }
 
 // RouteInfo 路由資訊
@@ -33,7 +57,39 @@ type RouteInfo struct {
 	Path        string
 	HandlerName string
 	Handler     *HandlerInfo
+	Registered  string // 註冊時實際產生 handler 的工廠、adapter 或包裝函數，見 registeredFunc
 	Group       string
+	Middlewares []*MiddlewareInfo
+	Security    []*SecurityInfo
+	Roles       []string
+	Scopes      []string
+
+	PathPatterns map[string]string // path 參數名 → regex 限制，例如 chi 的 {id:[0-9]+}
+	QueryParams  []string          // 路由比對時要求的 query 參數，例如 gorilla/mux 的 Queries()
+	Conditions   []string          // 註冊路由時外層的 if / switch 條件，由外而內
+	Position     token.Position    // 註冊路由的呼叫位置
+
+	pos        token.Pos
+	ownHandler bool // Handler 已複製成路由專屬，見 ownRouteHandler
+}
+
+// Tags 回傳 operation tag：handler 指定的 tag，未指定時取 group 的最後一段
+func (r *RouteInfo) Tags() []string {
+	if r.Handler != nil && len(r.Handler.Tags) > 0 {
+		return r.Handler.Tags
+	}
+	tag := strings.Trim(r.Group, "/")
+	if tag == "" {
+		return nil
+	}
+	parts := strings.Split(tag, "/")
+	return []string{parts[len(parts)-1]}
+}
+
+// MiddlewareInfo 路由套用的 middleware
+type MiddlewareInfo struct {
+	Name string
+	Args []string // 呼叫時的字串字面量引數
 }
 
 // HandlerInfo Handler 函數資訊
@@ -48,6 +104,14 @@ type HandlerInfo struct {
 	Parameters  []*ParameterInfo
 	RequestBody *TypeInfo
 	Responses   map[int]*ResponseInfo
+	Tags        []string // 指定的 operation tag，未指定時以 group 推斷
+
+	Position            token.Position // handler 宣告位置
+	RequestBodyPosition token.Position // bind request body 的呼叫位置
+
+	pos         token.Pos
+	stampedBody *TypeInfo // 已記錄位置的 request body
+	muxVars     []string  // 以 mux.Vars 讀取的 key，綁定路由後才知道是 path 還是 query 參數
 }
 
 // ParameterInfo 參數資訊
@@ -58,6 +122,7 @@ type ParameterInfo struct {
 	Required bool
 	Default  string
 	Comment  string
+	Position token.Position // 推斷出參數的程式碼位置，例如 c.Query 呼叫
 }
 
 // ResponseInfo 回應資訊
@@ -66,6 +131,8 @@ type ResponseInfo struct {
 	Type        *TypeInfo
 	IsArray     bool
 	Description string
+	ContentType string         // 空字串表示 application/json
+	Position    token.Position // 推斷出回應的程式碼位置，例如 c.JSON 呼叫
 }
 
 // TypeInfo 型別資訊
@@ -79,6 +146,46 @@ type TypeInfo struct {
 	Comment  string
 }
 
+// SecurityInfo 認證方式資訊（由 middleware 推斷）
+type SecurityInfo struct {
+	Type         string // http, apiKey
+	Scheme       string // basic, bearer（Type 為 http 時）
+	BearerFormat string
+	In           string // header, query, cookie（Type 為 apiKey 時）
+	Name         string // apiKey 名稱
+	Middleware   string
+}
+
+// 授權規則種類
+const (
+	AuthorizationRole  = "role"
+	AuthorizationScope = "scope"
+)
+
+// AuthorizationRule 授權 middleware 辨識規則
+// Function 可為簡名（RequireRole）或含 package 的名稱（auth.RequireRole），
+// 其字串字面量引數依 Kind 視為角色或 OAuth2 scope
+type AuthorizationRule struct {
+	Function string
+	Kind     string
+}
+
+// DefaultAuthorizationRules 內建的授權 middleware 規則
+func DefaultAuthorizationRules() []AuthorizationRule {
+	return []AuthorizationRule{
+		{Function: "RequireRole", Kind: AuthorizationRole},
+		{Function: "RequireRoles", Kind: AuthorizationRole},
+		{Function: "RequireAnyRole", Kind: AuthorizationRole},
+		{Function: "HasRole", Kind: AuthorizationRole},
+		{Function: "HasAnyRole", Kind: AuthorizationRole},
+		{Function: "RequireScope", Kind: AuthorizationScope},
+		{Function: "RequireScopes", Kind: AuthorizationScope},
+		{Function: "RequireAnyScope", Kind: AuthorizationScope},
+		{Function: "HasScope", Kind: AuthorizationScope},
+		{Function: "HasScopes", Kind: AuthorizationScope},
+	}
+}
+
 // FieldInfo 欄位資訊
 type FieldInfo struct {
 	Name     string
@@ -92,18 +199,42 @@ type FieldInfo struct {
 }
 
 func NewParser() *Parser {
-	return &Parser{
-		fset:                token.NewFileSet(),
-		packages:            make(map[string]*ast.Package),
-		Handlers:            make(map[string]*HandlerInfo),
-		Types:               make(map[string]*TypeInfo),
-		controllerInstances: make(map[string]string),
-		routeRegistrars:     make(map[string]*RouteRegistrar),
+	p := &Parser{
+		fset:                 token.NewFileSet(),
+		packages:             make(map[string]*ast.Package),
+		Handlers:             make(map[string]*HandlerInfo),
+		Types:                make(map[string]*TypeInfo),
+		controllerInstances:  make(map[string]string),
+		ambiguousInstances:   make(map[string]bool),
+		instanceScopes:       make(map[*token.File][]*instanceScope),
+		routeRegistrars:      make(map[string]*RouteRegistrar),
+		funcDecls:            make(map[string]*ast.FuncDecl),
+		methodTypes:          make(map[string]bool),
+		typedAdapters:        make(map[string]*ast.FuncDecl),
+		authMiddlewares:      make(map[string]*SecurityInfo),
+		authorizationRules:   DefaultAuthorizationRules(),
+		thirdPartyRegistrars: DefaultThirdPartyRegistrars(),
+		thirdPartyMode:       ThirdPartyDocument,
+		buildContext:         defaultBuildContext(),
 	}
+	p.frameworks = p.builtinFrameworks()
+	return p
 }
 
+// AddFramework 加入自訂框架 adapter，比對時優先於內建框架
+func (p *Parser) AddFramework(fw Framework) {
+	if spec, ok := fw.(FrameworkSpec); ok {
+		spec.p = p
+		fw = spec
+	}
+	p.customFrameworks = append(p.customFrameworks, fw)
+	p.frameworks = append(append([]Framework{}, p.customFrameworks...), p.builtinFrameworks()...)
+}
+
+// ParseDir 解析目錄下的所有 package；有語法錯誤時仍保留其他檔案的結果，並回傳 ParseErrors
 func (p *Parser) ParseDir(dir string) error {
-	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+	start := len(p.ParseErrors)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -122,14 +253,19 @@ func (p *Parser) ParseDir(dir string) error {
 				}
 			}
 
+			// 子目錄內的 go.mod 代表巢狀模組，其 package 以該模組的 import path 登記
+			if mod := parseGoMod(filepath.Join(path, "go.mod")); mod != nil {
+				p.addLocalModule(mod.path, mod)
+			}
+
 			pkgs, err := parser.ParseDir(p.fset, path, func(fi os.FileInfo) bool {
-				return !strings.HasSuffix(fi.Name(), "_test.go")
+				return p.matchBuildFile(path, fi)
 			}, parser.ParseComments)
 			if err != nil {
-				return nil
+				p.recordParseError(p.importPathOf(path), err)
 			}
-			for name, pkg := range pkgs {
-				p.packages[name] = pkg
+			for _, pkg := range pkgs {
+				p.packages[p.importPathOf(path)] = pkg
 				for _, file := range pkg.Files {
 					p.files = append(p.files, file)
 				}
@@ -137,33 +273,49 @@ func (p *Parser) ParseDir(dir string) error {
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	return p.parseErrorsSince(start)
 }
 
 func (p *Parser) ParseFile(filename string) error {
 	file, err := parser.ParseFile(p.fset, filename, nil, parser.ParseComments)
 	if err != nil {
-		return err
+		start := len(p.ParseErrors)
+		p.recordParseError(p.importPathOf(filepath.Dir(filename)), err)
+		return p.parseErrorsSince(start)
 	}
 	p.files = append(p.files, file)
 	return nil
 }
 
 func (p *Parser) Analyze() error {
+	// 外部 module 的型別先載入，本地同名型別會覆蓋其簡名
+	if p.parseDependency {
+		p.loadDependencyTypes()
+	}
 	for _, file := range p.files {
 		p.extractTypes(file)
 	}
 	for _, file := range p.files {
 		p.extractHandlers(file)
+		p.indexFuncDecls(file)
 	}
 
 	// P3 修復：收集並註冊閉包工廠函數的 handler
-	closureFactories := p.collectClosureFactories()
-	p.registerClosureHandlers(closureFactories)
+	p.closureFactories = p.collectClosureFactories()
+	p.registerClosureHandlers(p.closureFactories)
 
 	for _, file := range p.files {
 		p.extractControllerInstances(file)
 	}
 
+	// 泛型 adapter 包裝的 handler 需要 controller instance 才能解析方法名稱
+	p.collectTypedAdapters()
+	p.registerTypedHandlers()
+	p.registerSpecializedHandlers()
+
 	// 先收集 route registrars，這樣 extractRoutes 可以跳過這些函數
 	p.routeRegistrars = p.collectRouteRegistrars()
 
@@ -174,31 +326,51 @@ func (p *Parser) Analyze() error {
 	// P1 修復：追蹤跨檔案的 RouterGroup 傳遞
 	callSites := p.findCallSites(p.routeRegistrars)
 	for _, cs := range callSites {
-		p.extractRoutesWithPrefix(cs.Registrar, cs.GroupPrefix)
+		p.extractRoutesFromCallSite(cs)
 	}
 
 	// Phase 3：追蹤透過函數引數間接傳遞的 registrar
 	indirectSites := p.findIndirectCallSites(p.routeRegistrars)
 	for _, cs := range indirectSites {
-		p.extractRoutesWithPrefix(cs.Registrar, cs.GroupPrefix)
+		p.extractRoutesFromCallSite(cs)
+	}
+
+	// chi：沒有被呼叫或掛載的 router 建構函數以根路徑解析
+	p.extractStandaloneRouters()
+
+	if p.pruneUnreachable {
+		p.pruneUnreachableRoutes()
 	}
+	p.applyRouteConditions()
+	p.excludeThirdPartyHandlerRoutes()
+	p.stampHandlerDecls()
 
 	for _, route := range p.Routes {
 		if handler, ok := p.Handlers[route.HandlerName]; ok {
 			route.Handler = handler
+			p.addMuxVarsParams(route)
 			p.addPathParams(route)
+			p.addRouteQueryParams(route)
+		} else if handler := p.knownHandlerInfo(route.HandlerName); handler != nil {
+			route.Handler = handler
 		} else {
 			for key, handler := range p.Handlers {
 				if strings.HasSuffix(key, "."+p.getSimpleName(route.HandlerName)) {
 					route.Handler = handler
 					route.HandlerName = key
+					p.addMuxVarsParams(route)
 					p.addPathParams(route)
+					p.addRouteQueryParams(route)
 					break
 				}
 			}
 		}
 	}
 
+	p.stampRoutes()
+	p.resolveRouteSecurity()
+	p.reportInferenceGaps()
+
 	return nil
 }
 
@@ -219,6 +391,7 @@ func (p *Parser) addPathParams(route *RouteInfo) {
 
 	for _, match := range matches {
 		if len(match) > 1 && !existingParams[match[1]] {
+			ownRouteHandler(route)
 			route.Handler.Parameters = append(route.Handler.Parameters, &ParameterInfo{
 				Name:     match[1],
 				Type:     "string",
@@ -229,7 +402,50 @@ func (p *Parser) addPathParams(route *RouteInfo) {
 	}
 }
 
+// addRouteQueryParams 把路由層級要求的 query 參數補進 handler 參數
+func (p *Parser) addRouteQueryParams(route *RouteInfo) {
+	if route.Handler == nil {
+		return
+	}
+
+	for _, name := range route.QueryParams {
+		ownRouteHandler(route)
+		found := false
+		for i, param := range route.Handler.Parameters {
+			if param.In == "query" && param.Name == name {
+				required := *param
+				required.Required = true
+				route.Handler.Parameters[i] = &required
+				found = true
+			}
+		}
+		if !found {
+			route.Handler.Parameters = append(route.Handler.Parameters, &ParameterInfo{
+				Name:     name,
+				Type:     "string",
+				In:       "query",
+				Required: true,
+			})
+		}
+	}
+}
+
+// ownRouteHandler 讓路由持有自己的 HandlerInfo 與參數 slice
+// 路由層級的參數只屬於該路由，不能寫進多個路由共用的 handler
+func ownRouteHandler(route *RouteInfo) {
+	if route.ownHandler {
+		return
+	}
+	handler := *route.Handler
+	handler.Parameters = append([]*ParameterInfo(nil), route.Handler.Parameters...)
+	route.Handler = &handler
+	route.ownHandler = true
+}
+
 func (p *Parser) findType(name string) *TypeInfo {
+	if sub, ok := p.typeSubst[name]; ok {
+		name = strings.TrimPrefix(sub, "*")
+	}
 	if t, ok := p.Types[name]; ok {
 		return t
 	}
@@ -0,0 +1,89 @@
+package swaggo
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"strings"
+)
+
+// inspectHandlerBody 走訪 handler body，callback 新增的參數、request body 與回應記錄為當下節點的位置，
+// 例如 c.Query("page") 的參數指向該呼叫，c.JSON(200, resp) 的回應指向該行
+func (p *Parser) inspectHandlerBody(node ast.Node, handler *HandlerInfo, fn func(ast.Node) bool) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		cont := fn(n)
+		if n != nil {
+			p.stampProvenance(handler, n.Pos())
+		}
+		return cont
+	})
+}
+
+// stampProvenance 把尚未記錄來源位置的參數、request body 與回應標記為 pos
+func (p *Parser) stampProvenance(handler *HandlerInfo, pos token.Pos) {
+	if handler == nil || !pos.IsValid() {
+		return
+	}
+	var position token.Position
+	resolve := func() token.Position {
+		if !position.IsValid() {
+			position = p.fset.Position(pos)
+		}
+		return position
+	}
+
+	for _, param := range handler.Parameters {
+		if !param.Position.IsValid() {
+			param.Position = resolve()
+		}
+	}
+	// request body 被後面的 bind 覆蓋時，位置跟著更新
+	if handler.RequestBody != nil && handler.RequestBody != handler.stampedBody {
+		handler.RequestBodyPosition = resolve()
+		handler.stampedBody = handler.RequestBody
+	}
+	for _, resp := range handler.Responses {
+		if !resp.Position.IsValid() {
+			resp.Position = resolve()
+		}
+	}
+}
+
+// stampHandlerDecls 以 handler 宣告位置補齊 body 分析以外推斷出的項目，例如泛型 adapter 的參數
+func (p *Parser) stampHandlerDecls() {
+	for _, handler := range p.Handlers {
+		if handler.pos.IsValid() {
+			handler.Position = p.fset.Position(handler.pos)
+		}
+		p.stampProvenance(handler, handler.pos)
+	}
+}
+
+// stampRoutes 記錄路由的註冊位置；由路由推斷的 path / query 參數與內建 handler 的回應同樣指向註冊位置
+func (p *Parser) stampRoutes() {
+	for _, route := range p.Routes {
+		if !route.pos.IsValid() {
+			continue
+		}
+		route.Position = p.fset.Position(route.pos)
+		p.stampProvenance(route.Handler, route.pos)
+	}
+}
+
+// sourceRef 以相對於專案根目錄的 file:line 表示來源位置，未啟用 x-source 時回傳空字串
+func (g *Generator) sourceRef(pos token.Position) string {
+	if !g.sourceExt || !pos.IsValid() {
+		return ""
+	}
+	file, err := filepath.Abs(pos.Filename)
+	if err != nil {
+		file = pos.Filename
+	}
+	if root, err := filepath.Abs(g.sourceRoot); err == nil && g.sourceRoot != "" {
+		if rel, err := filepath.Rel(root, file); err == nil && !strings.HasPrefix(rel, "..") {
+			file = rel
+		}
+	}
+	return fmt.Sprintf("%s:%d", filepath.ToSlash(file), pos.Line)
+}
@@ -0,0 +1,91 @@
+package swaggo
+
+import "testing"
+
+func TestSourceProvenance(t *testing.T) {
+	p := analyzeSource(t, `package main
+
+import "github.com/gin-gonic/gin"
+
+func main() {
+	r := gin.Default()
+	api := r.Group("/api")
+	api.POST("/users/:id", UpdateUser)
+}
+
+type UpdateUserRequest struct {
+	Name string `+"`json:\"name\"`"+`
+}
+
+func UpdateUser(c *gin.Context) {
+	page := c.Query("page")
+	var req UpdateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, req)
+	_ = page
+}
+`)
+
+	route := findRoute(p, "POST", "/api/users/:id")
+	if route == nil {
+		t.Fatal("route not found")
+	}
+	if route.Position.Line != 8 {
+		t.Errorf("route line = %d, want 8", route.Position.Line)
+	}
+
+	handler := route.Handler
+	if handler.Position.Line != 15 {
+		t.Errorf("handler line = %d, want 15", handler.Position.Line)
+	}
+	if handler.RequestBodyPosition.Line != 18 {
+		t.Errorf("request body line = %d, want 18", handler.RequestBodyPosition.Line)
+	}
+
+	paramLines := map[string]int{"page": 16, "id": 8}
+	for _, param := range handler.Parameters {
+		if want, ok := paramLines[param.Name]; ok && param.Position.Line != want {
+			t.Errorf("param %s line = %d, want %d", param.Name, param.Position.Line, want)
+		}
+	}
+	for code, want := range map[int]int{400: 19, 200: 22} {
+		if got := handler.Responses[code].Position.Line; got != want {
+			t.Errorf("%d response line = %d, want %d", code, got, want)
+		}
+	}
+
+	gen := New()
+	gen.parser = p
+	spec, err := gen.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if op := spec.Paths["/api/users/{id}"].Post; op.XSource != "" {
+		t.Errorf("x-source should be omitted by default, got %q", op.XSource)
+	}
+
+	gen.SetSourceExtensions(true)
+	gen.sourceRoot = "."
+	spec, err = gen.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	op := spec.Paths["/api/users/{id}"].Post
+	if op.XSource != "main.go:8" {
+		t.Errorf("operation x-source = %q, want main.go:8", op.XSource)
+	}
+	if op.RequestBody.XSource != "main.go:18" {
+		t.Errorf("request body x-source = %q, want main.go:18", op.RequestBody.XSource)
+	}
+	if got := op.Responses["200"].XSource; got != "main.go:22" {
+		t.Errorf("200 response x-source = %q, want main.go:22", got)
+	}
+	for _, param := range op.Parameters {
+		if param.Name == "page" && param.XSource != "main.go:16" {
+			t.Errorf("page x-source = %q, want main.go:16", param.XSource)
+		}
+	}
+}
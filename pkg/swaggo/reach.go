@@ -0,0 +1,161 @@
+package swaggo
+
+import (
+	"go/ast"
+	"path/filepath"
+)
+
+// pruneUnreachableRoutes 以 main（與 init、package 層級變數的初始化）為起點建立呼叫圖，
+// 移除註冊位置不在可到達函數內的路由
+func (p *Parser) pruneUnreachableRoutes() {
+	declFiles := make(map[*ast.FuncDecl]*ast.File)
+	var roots []reachRoot
+	hasMain := false
+	for _, file := range p.files {
+		isEntry := p.isEntryPackageFile(file)
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				declFiles[d] = file
+				isMain := d.Name.Name == "main" && isEntry
+				if d.Recv == nil && (d.Name.Name == "init" || isMain) {
+					roots = append(roots, reachRoot{node: d, file: file})
+					hasMain = hasMain || isMain
+				}
+			case *ast.GenDecl:
+				roots = append(roots, reachRoot{node: d, file: file})
+			}
+		}
+	}
+	if !hasMain {
+		return
+	}
+
+	reachable := p.reachableFuncs(roots, declFiles)
+	kept := p.Routes[:0]
+	for _, route := range p.Routes {
+		fn := enclosingFuncDecl(declFiles, route)
+		if fn == nil || reachable[fn] {
+			kept = append(kept, route)
+			continue
+		}
+		p.addRouteDiagnostic(route, DiagUnreachableRoute, route.pos, "registered in %s, which is not reachable from main",
+			p.buildFuncFullName(fn, declFiles[fn].Name.Name))
+	}
+	p.Routes = kept
+}
+
+// isEntryPackageFile 判斷檔案是否屬於入口的 main package；未指定入口時所有 main package 都算
+func (p *Parser) isEntryPackageFile(file *ast.File) bool {
+	if file.Name.Name != "main" {
+		return false
+	}
+	if p.entryFile == "" {
+		return true
+	}
+	dir, err := filepath.Abs(filepath.Dir(p.fset.Position(file.Pos()).Filename))
+	return err == nil && dir == filepath.Dir(p.entryFile)
+}
+
+// reachRoot 呼叫圖的起點與其所在檔案
+type reachRoot struct {
+	node ast.Node
+	file *ast.File
+}
+
+// reachableFuncs 由 roots 出發找出所有被呼叫或以函數值引用的函數
+// 無法確定接收者型別的 x.Method 保守地視為所有同名 method 都可到達
+func (p *Parser) reachableFuncs(roots []reachRoot, declFiles map[*ast.FuncDecl]*ast.File) map[*ast.FuncDecl]bool {
+	methodsByName := make(map[string][]*ast.FuncDecl)
+	for fn := range declFiles {
+		if fn.Recv != nil {
+			methodsByName[fn.Name.Name] = append(methodsByName[fn.Name.Name], fn)
+		}
+	}
+
+	reachable := make(map[*ast.FuncDecl]bool)
+	queue := append([]reachRoot{}, roots...)
+	visit := func(fn *ast.FuncDecl) {
+		if fn != nil && !reachable[fn] {
+			reachable[fn] = true
+			queue = append(queue, reachRoot{node: fn, file: declFiles[fn]})
+		}
+	}
+
+	packageNames := make(map[*ast.File]map[string]string)
+	for len(queue) > 0 {
+		root := queue[0]
+		queue = queue[1:]
+		pkgName := root.file.Name.Name
+		imports, ok := packageNames[root.file]
+		if !ok {
+			imports = p.importedPackageNames(root.file)
+			packageNames[root.file] = imports
+		}
+
+		node := root.node
+		if fn, ok := node.(*ast.FuncDecl); ok {
+			reachable[fn] = true
+			if fn.Body == nil {
+				continue
+			}
+			node = fn.Body
+		}
+
+		ast.Inspect(node, func(n ast.Node) bool {
+			switch expr := n.(type) {
+			case *ast.Ident:
+				visit(p.funcDecls[pkgName+"."+expr.Name])
+			case *ast.SelectorExpr:
+				if ident, ok := expr.X.(*ast.Ident); ok {
+					// funcDecls 以 package 名稱索引，import alias 需先轉回 package 名稱
+					pkg := ident.Name
+					if name, ok := imports[pkg]; ok {
+						pkg = name
+					}
+					if fn := p.funcDecls[pkg+"."+expr.Sel.Name]; fn != nil {
+						visit(fn)
+						return true
+					}
+					if typeName, ok := p.instanceType(ident); ok {
+						if fn := p.funcDecls[typeName+"."+expr.Sel.Name]; fn != nil {
+							visit(fn)
+							return true
+						}
+					}
+				}
+				for _, fn := range methodsByName[expr.Sel.Name] {
+					visit(fn)
+				}
+			}
+			return true
+		})
+	}
+	return reachable
+}
+
+// enclosingFuncDecl 找出註冊路由的呼叫所在的函數，package 層級的宣告回傳 nil
+func enclosingFuncDecl(declFiles map[*ast.FuncDecl]*ast.File, route *RouteInfo) *ast.FuncDecl {
+	if !route.pos.IsValid() {
+		return nil
+	}
+	for fn := range declFiles {
+		if route.pos >= fn.Pos() && route.pos < fn.End() {
+			return fn
+		}
+	}
+	return nil
+}
+
+// importedPackageNames 回傳檔案中 import 名稱（含 alias）→ 被 import 的 package 名稱
+func (p *Parser) importedPackageNames(file *ast.File) map[string]string {
+	names := make(map[string]string)
+	for alias, path := range fileImports(file) {
+		if pkg, ok := p.packages[path]; ok {
+			names[alias] = pkg.Name
+		} else {
+			names[alias] = importPackageName(path)
+		}
+	}
+	return names
+}
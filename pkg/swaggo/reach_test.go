@@ -0,0 +1,125 @@
+package swaggo
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestPruneUnreachableRoutes(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"go.mod": "module example.com/app\n",
+		"cmd/api/main.go": `package main
+
+import (
+	"github.com/gin-gonic/gin"
+	"example.com/app/routes"
+)
+
+func main() {
+	r := gin.Default()
+	routes.Register(r)
+}
+`,
+		"routes/routes.go": `package routes
+
+import "github.com/gin-gonic/gin"
+
+type Users struct{}
+
+func Register(r *gin.Engine) {
+	u := &Users{}
+	u.Mount(r)
+}
+
+func (u *Users) Mount(r *gin.Engine) {
+	r.GET("/users", List)
+}
+
+// RegisterLegacy 只有舊版 binary 使用
+func RegisterLegacy(r *gin.Engine) {
+	r.GET("/legacy", List)
+}
+
+func List(c *gin.Context) { c.JSON(200, nil) }
+`,
+		"cmd/api/legacy.go": `package main
+
+import (
+	"github.com/gin-gonic/gin"
+	"example.com/app/routes"
+)
+
+// legacyMain 已不再被呼叫
+func legacyMain() {
+	r := gin.Default()
+	routes.RegisterLegacy(r)
+	r.GET("/old-health", routes.List)
+}
+`,
+	})
+
+	gen := New()
+	gen.SetPruneUnreachable(true)
+	if err := gen.ParseFromEntry(filepath.Join(root, "cmd", "api", "main.go"), root); err != nil {
+		t.Fatal(err)
+	}
+	p := gen.parser
+
+	if findRoute(p, "GET", "/users") == nil {
+		t.Error("expected reachable GET /users")
+	}
+	for _, path := range []string{"/legacy", "/old-health"} {
+		if findRoute(p, "GET", path) != nil {
+			t.Errorf("expected GET %s to be pruned", path)
+		}
+	}
+
+	var unreachable int
+	for _, d := range gen.Diagnostics() {
+		if d.Code == DiagUnreachableRoute && d.Position.IsValid() {
+			unreachable++
+		}
+	}
+	if unreachable != 2 {
+		t.Errorf("expected 2 unreachable-route diagnostics, got %v", gen.Diagnostics())
+	}
+}
+
+func TestPruneUnreachableFollowsImportAlias(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"go.mod": "module example.com/app\n",
+		"users/routes.go": `package users
+
+import "github.com/gin-gonic/gin"
+
+func Register(r *gin.Engine) {
+	r.GET("/users", List)
+}
+
+func List(c *gin.Context) { c.JSON(200, nil) }
+`,
+		"main.go": `package main
+
+import (
+	"github.com/gin-gonic/gin"
+	u "example.com/app/users"
+)
+
+func main() {
+	r := gin.Default()
+	u.Register(r)
+}
+`,
+	})
+
+	gen := New()
+	gen.SetPruneUnreachable(true)
+	if err := gen.ParseFromEntry(filepath.Join(root, "main.go"), root); err != nil {
+		t.Fatal(err)
+	}
+	if findRoute(gen.parser, "GET", "/users") == nil {
+		t.Errorf("route registered through an import alias was pruned: %v", gen.Diagnostics())
+	}
+}
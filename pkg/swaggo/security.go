@@ -0,0 +1,449 @@
+package swaggo
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// indexFuncDecls 以 FullName 索引檔案中的函數宣告，供 middleware 分析查詢
+func (p *Parser) indexFuncDecls(file *ast.File) {
+	pkgName := file.Name.Name
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		fullName := p.buildFuncFullName(fn, pkgName)
+		p.funcDecls[fullName] = fn
+		if fn.Recv != nil {
+			p.methodTypes[strings.TrimSuffix(fullName, "."+fn.Name.Name)] = true
+		}
+	}
+}
+
+// findFuncDecl 以完整名稱查詢函數宣告，找不到時以簡名後綴匹配
+// 後綴匹配到多個宣告（例如兩個 package 都有 Auth）時無法判斷，回傳 nil
+func (p *Parser) findFuncDecl(name string) *ast.FuncDecl {
+	if fn, ok := p.funcDecls[name]; ok {
+		return fn
+	}
+	simpleName := p.getSimpleName(name)
+	var found *ast.FuncDecl
+	for key, fn := range p.funcDecls {
+		if strings.HasSuffix(key, "."+simpleName) {
+			if found != nil {
+				return nil
+			}
+			found = fn
+		}
+	}
+	return found
+}
+
+// collectUseCall 處理 group.Use(mw...)，把 middleware 累加到該 group 變數
+func (p *Parser) collectUseCall(call *ast.CallExpr, pkgName string, middlewares map[string][]*MiddlewareInfo) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Use" || len(call.Args) == 0 {
+		return
+	}
+
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return
+	}
+
+	resolved := p.resolveMiddlewares(call.Args, pkgName)
+	middlewares[ident.Name] = appendMiddlewares(middlewares[ident.Name], resolved...)
+}
+
+// updateGroupMiddlewares 處理 g := parent.Group("/x", mw...)，子 group 繼承 parent 的 middleware
+func (p *Parser) updateGroupMiddlewares(assign *ast.AssignStmt, pkgName string, middlewares map[string][]*MiddlewareInfo) {
+	for i, rhs := range assign.Rhs {
+		info := p.extractGroupCall(rhs)
+		if info == nil {
+			continue
+		}
+
+		varName := p.getAssignTarget(assign.Lhs, i)
+		if varName == "" {
+			continue
+		}
+
+		inherited := middlewares[info.parentVar]
+		middlewares[varName] = appendMiddlewares(inherited, p.resolveMiddlewares(info.middlewares, pkgName)...)
+	}
+}
+
+// collectGroupMiddlewares 收集整個檔案中各 group 變數套用的 middleware
+func (p *Parser) collectGroupMiddlewares(file *ast.File) map[string][]*MiddlewareInfo {
+	pkgName := file.Name.Name
+	middlewares := make(map[string][]*MiddlewareInfo)
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			p.updateGroupMiddlewares(node, pkgName, middlewares)
+		case *ast.CallExpr:
+			p.collectUseCall(node, pkgName, middlewares)
+		}
+		return true
+	})
+
+	return middlewares
+}
+
+func (p *Parser) resolveGroupMiddlewares(expr ast.Expr, middlewares map[string][]*MiddlewareInfo) []*MiddlewareInfo {
+	if ident, ok := routerReceiver(expr).(*ast.Ident); ok {
+		return middlewares[ident.Name]
+	}
+	return nil
+}
+
+// routeMiddlewares 合併 group 的 middleware 與路由呼叫本身帶的 middleware
+// 例如：r.GET("/x", Auth(), h.Get) 的 Auth()
+func (p *Parser) routeMiddlewares(rc *RouteCall, pkgName string, groupMiddlewares []*MiddlewareInfo) []*MiddlewareInfo {
+	return appendMiddlewares(groupMiddlewares, p.resolveMiddlewares(rc.Middlewares, pkgName)...)
+}
+
+// resolveMiddlewares 解析 middleware 名稱，並保留呼叫時的字串字面量引數
+// 例如：RequireRole("admin") → {Name: "main.RequireRole", Args: ["admin"]}
+func (p *Parser) resolveMiddlewares(exprs []ast.Expr, pkgName string) []*MiddlewareInfo {
+	var result []*MiddlewareInfo
+	for _, expr := range exprs {
+		name := p.resolveHandlerName(expr, pkgName)
+		if name == "" {
+			continue
+		}
+		mw := &MiddlewareInfo{Name: name}
+		if call, ok := expr.(*ast.CallExpr); ok {
+			mw.Args = p.extractStringArgs(call.Args)
+		}
+		result = append(result, mw)
+	}
+	return result
+}
+
+// extractStringArgs 收集字串字面量引數，包含 []string{"a", "b"} 形式
+func (p *Parser) extractStringArgs(args []ast.Expr) []string {
+	var values []string
+	for _, arg := range args {
+		if cl, ok := arg.(*ast.CompositeLit); ok {
+			values = append(values, p.extractStringArgs(cl.Elts)...)
+			continue
+		}
+		if value := p.extractStringArg(arg); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// appendMiddlewares 回傳新 slice，避免不同 group 共用底層陣列
+func appendMiddlewares(base []*MiddlewareInfo, added ...*MiddlewareInfo) []*MiddlewareInfo {
+	if len(added) == 0 {
+		return base
+	}
+	result := make([]*MiddlewareInfo, 0, len(base)+len(added))
+	result = append(result, base...)
+	return append(result, added...)
+}
+
+// resolveRouteSecurity 根據每條路由的 middleware 推斷認證方式與授權需求
+func (p *Parser) resolveRouteSecurity() {
+	for _, route := range p.Routes {
+		route.Security = nil
+		route.Roles = nil
+		route.Scopes = nil
+		for _, mw := range route.Middlewares {
+			if info := p.detectAuthMiddleware(mw.Name); info != nil {
+				route.Security = append(route.Security, info)
+			}
+			if rule := p.matchAuthorizationRule(mw.Name); rule != nil {
+				switch rule.Kind {
+				case AuthorizationRole:
+					route.Roles = appendUnique(route.Roles, mw.Args...)
+				case AuthorizationScope:
+					route.Scopes = appendUnique(route.Scopes, mw.Args...)
+				}
+			}
+		}
+	}
+}
+
+// matchAuthorizationRule 以完整名稱或簡名比對授權 middleware 規則
+func (p *Parser) matchAuthorizationRule(name string) *AuthorizationRule {
+	for i := range p.authorizationRules {
+		rule := &p.authorizationRules[i]
+		if rule.Function == name || strings.HasSuffix(name, "."+rule.Function) {
+			return rule
+		}
+	}
+	return nil
+}
+
+func appendUnique(values []string, added ...string) []string {
+	for _, v := range added {
+		exists := false
+		for _, existing := range values {
+			if existing == v {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// detectAuthMiddleware 判斷 middleware 是否為認證 middleware，結果會快取
+func (p *Parser) detectAuthMiddleware(name string) *SecurityInfo {
+	if info, ok := p.authMiddlewares[name]; ok {
+		return info
+	}
+
+	info := knownAuthMiddleware(name)
+	if info == nil {
+		if fn := p.findFuncDecl(name); fn != nil {
+			info = p.analyzeAuthMiddlewareBody(fn)
+		}
+	}
+	if info != nil {
+		info.Middleware = name
+	}
+
+	p.authMiddlewares[name] = info
+	return info
+}
+
+// knownAuthMiddleware 辨識 gin/echo 內建與常見 JWT/OAuth 套件的認證 middleware
+func knownAuthMiddleware(name string) *SecurityInfo {
+	switch name {
+	case "gin.BasicAuth", "gin.BasicAuthForRealm",
+		"middleware.BasicAuth", "middleware.BasicAuthWithConfig": // echo
+		return &SecurityInfo{Type: "http", Scheme: "basic"}
+	case "middleware.KeyAuth", "middleware.KeyAuthWithConfig": // echo，預設讀 Authorization: Bearer
+		return &SecurityInfo{Type: "http", Scheme: "bearer"}
+	case "middleware.JWT", "middleware.JWTWithConfig": // echo
+		return &SecurityInfo{Type: "http", Scheme: "bearer", BearerFormat: "JWT"}
+	}
+
+	// appleboy/gin-jwt: authMiddleware.MiddlewareFunc()
+	if strings.HasSuffix(name, ".MiddlewareFunc") {
+		return &SecurityInfo{Type: "http", Scheme: "bearer", BearerFormat: "JWT"}
+	}
+
+	pkg := name
+	if dot := strings.Index(name, "."); dot >= 0 {
+		pkg = name[:dot]
+	}
+	switch pkg {
+	case "jwt", "ginjwt", "jwtauth", "jwtmiddleware", "echojwt":
+		return &SecurityInfo{Type: "http", Scheme: "bearer", BearerFormat: "JWT"}
+	case "ginoauth2", "oauth2", "oidc":
+		return &SecurityInfo{Type: "http", Scheme: "bearer"}
+	case "basicauth": // fiber
+		return &SecurityInfo{Type: "http", Scheme: "basic"}
+	case "keyauth": // fiber，預設讀 Authorization: Bearer
+		return &SecurityInfo{Type: "http", Scheme: "bearer"}
+	}
+
+	return nil
+}
+
+// analyzeAuthMiddlewareBody 分析專案自訂 middleware 讀取哪些憑證
+func (p *Parser) analyzeAuthMiddlewareBody(fn *ast.FuncDecl) *SecurityInfo {
+	if fn.Body == nil {
+		return nil
+	}
+
+	var (
+		readsAuthorization bool
+		hasBearer          bool
+		hasBasic           bool
+		usesJWT            bool
+		apiKeyHeader       string
+		apiKeyCookie       string
+		apiKeyQuery        string
+	)
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.BasicLit:
+			if node.Kind != token.STRING {
+				return true
+			}
+			value, _ := strconv.Unquote(node.Value)
+			value = strings.TrimSpace(value)
+			if strings.HasPrefix(value, "Bearer") {
+				hasBearer = true
+			} else if strings.HasPrefix(value, "Basic") {
+				hasBasic = true
+			}
+
+		case *ast.CallExpr:
+			sel, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "jwt" {
+				usesJWT = true
+			}
+
+			arg := ""
+			if len(node.Args) > 0 {
+				arg = p.extractStringArg(node.Args[0])
+			}
+
+			switch sel.Sel.Name {
+			case "BasicAuth":
+				hasBasic = true
+				readsAuthorization = true
+			case "GetHeader":
+				readsAuthorization = readsAuthorization || strings.EqualFold(arg, "Authorization")
+				if apiKeyHeader == "" && isCredentialName(arg) && !strings.EqualFold(arg, "Authorization") {
+					apiKeyHeader = arg
+				}
+			case "Get":
+				if inner, ok := sel.X.(*ast.SelectorExpr); ok && inner.Sel.Name == "Header" {
+					readsAuthorization = readsAuthorization || strings.EqualFold(arg, "Authorization")
+					if apiKeyHeader == "" && isCredentialName(arg) && !strings.EqualFold(arg, "Authorization") {
+						apiKeyHeader = arg
+					}
+				}
+				if inner, ok := sel.X.(*ast.CallExpr); ok {
+					if innerSel, ok := inner.Fun.(*ast.SelectorExpr); ok && innerSel.Sel.Name == "Query" {
+						if apiKeyQuery == "" && isCredentialName(arg) {
+							apiKeyQuery = arg
+						}
+					}
+				}
+			case "Cookie":
+				if apiKeyCookie == "" && isCredentialName(arg) {
+					apiKeyCookie = arg
+				}
+			case "Query", "DefaultQuery", "GetQuery", "QueryParam":
+				if apiKeyQuery == "" && isCredentialName(arg) {
+					apiKeyQuery = arg
+				}
+			}
+		}
+		return true
+	})
+
+	switch {
+	case readsAuthorization || hasBearer:
+		if hasBasic && !hasBearer {
+			return &SecurityInfo{Type: "http", Scheme: "basic"}
+		}
+		info := &SecurityInfo{Type: "http", Scheme: "bearer"}
+		if usesJWT {
+			info.BearerFormat = "JWT"
+		}
+		return info
+	case apiKeyHeader != "":
+		return &SecurityInfo{Type: "apiKey", In: "header", Name: apiKeyHeader}
+	case apiKeyCookie != "":
+		return &SecurityInfo{Type: "apiKey", In: "cookie", Name: apiKeyCookie}
+	case apiKeyQuery != "":
+		return &SecurityInfo{Type: "apiKey", In: "query", Name: apiKeyQuery}
+	case usesJWT:
+		return &SecurityInfo{Type: "http", Scheme: "bearer", BearerFormat: "JWT"}
+	}
+
+	return nil
+}
+
+// credentialWords 單獨出現即代表憑證的字
+var credentialWords = map[string]bool{
+	"authorization": true, "auth": true, "jwt": true, "session": true, "sid": true,
+	"secret": true, "credential": true, "credentials": true, "apikey": true, "password": true,
+	"sessionid": true, "jsessionid": true, "phpsessid": true, "accesstoken": true, "authtoken": true,
+}
+
+// credentialQualifiers 接在 token / key 前面時代表憑證的字，例如 X-Access-Token、api_key；
+// page_token、X-Idempotency-Key、sort_key 則不是
+var credentialQualifiers = map[string]bool{
+	"x": true, "access": true, "api": true, "bearer": true, "id": true, "refresh": true, "client": true, "user": true,
+}
+
+// isCredentialName 判斷 header/cookie/query 名稱是否像憑證，以完整的字比對，author、page_token 不算
+func isCredentialName(name string) bool {
+	words := nameWords(name)
+	for i, word := range words {
+		if credentialWords[word] {
+			return true
+		}
+		if word == "token" || word == "key" {
+			if len(words) == 1 || (i > 0 && credentialQualifiers[words[i-1]]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nameWords 以分隔符號與大小寫邊界拆字並轉小寫，例如 X-API-Key → [x api key]、accessToken → [access token]
+func nameWords(name string) []string {
+	var words []string
+	runes := []rune(name)
+	start := -1
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, strings.ToLower(string(runes[start:i])))
+				start = -1
+			}
+			continue
+		}
+		if start >= 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				words = append(words, strings.ToLower(string(runes[start:i])))
+				start = i
+			}
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, strings.ToLower(string(runes[start:])))
+	}
+	return words
+}
+
+// securitySchemeName 產生 components.securitySchemes 的 key
+func securitySchemeName(info *SecurityInfo) string {
+	switch info.Type {
+	case "http":
+		return info.Scheme + "Auth"
+	case "apiKey":
+		in := info.In
+		if in != "" {
+			in = strings.ToUpper(in[:1]) + in[1:]
+		}
+		return "apiKey" + in + "_" + sanitizeComponentName(info.Name)
+	}
+	return info.Type
+}
+
+// sanitizeComponentName 只保留 OpenAPI component key 允許的字元
+func sanitizeComponentName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,392 @@
+package swaggo
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestSecurityFromBuiltinBasicAuth(t *testing.T) {
+	src := `package main
+
+import "github.com/gin-gonic/gin"
+
+func main() {
+	r := gin.Default()
+	r.GET("/health", Health)
+
+	admin := r.Group("/admin", gin.BasicAuth(gin.Accounts{"admin": "secret"}))
+	admin.GET("/stats", Stats)
+}
+
+func Health(c *gin.Context) {}
+func Stats(c *gin.Context) {}
+`
+	p := analyzeSource(t, src)
+
+	health := findRoute(p, "GET", "/health")
+	if health == nil || len(health.Security) != 0 {
+		t.Fatalf("expected /health without security, got %+v", health)
+	}
+
+	stats := findRoute(p, "GET", "/admin/stats")
+	if stats == nil {
+		t.Fatal("expected route GET /admin/stats")
+	}
+	if len(stats.Security) != 1 || stats.Security[0].Scheme != "basic" {
+		t.Errorf("expected basic auth on /admin/stats, got %+v", stats.Security)
+	}
+}
+
+func TestSecurityFromCustomMiddleware(t *testing.T) {
+	src := `package main
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+func JWTAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		_ = token
+		c.Next()
+	}
+}
+
+func APIKeyAuth(c *gin.Context) {
+	if c.GetHeader("X-API-Key") == "" {
+		c.AbortWithStatus(401)
+	}
+}
+
+func Logger() gin.HandlerFunc {
+	return func(c *gin.Context) { c.Next() }
+}
+
+func main() {
+	r := gin.Default()
+	r.Use(Logger())
+
+	api := r.Group("/api")
+	api.Use(JWTAuth())
+	api.GET("/me", Me)
+
+	r.GET("/partner", APIKeyAuth, Partner)
+}
+
+func Me(c *gin.Context) {
+	_ = c.GetHeader("Authorization")
+	_ = c.Query("fields")
+}
+
+func Partner(c *gin.Context) {
+	_ = c.GetHeader("X-API-Key")
+}
+`
+	p := analyzeSource(t, src)
+
+	me := findRoute(p, "GET", "/api/me")
+	if me == nil {
+		t.Fatal("expected route GET /api/me")
+	}
+	if len(me.Security) != 1 || me.Security[0].Scheme != "bearer" {
+		t.Fatalf("expected bearer auth on /api/me, got %+v", me.Security)
+	}
+
+	partner := findRoute(p, "GET", "/partner")
+	if partner == nil {
+		t.Fatal("expected route GET /partner")
+	}
+	if len(partner.Security) != 1 || partner.Security[0].Type != "apiKey" || partner.Security[0].Name != "X-API-Key" {
+		t.Fatalf("expected apiKey X-API-Key on /partner, got %+v", partner.Security)
+	}
+
+	gen := New()
+	gen.parser = p
+	spec, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("generate error: %v", err)
+	}
+
+	if _, ok := spec.Components.SecuritySchemes["bearerAuth"]; !ok {
+		t.Errorf("expected bearerAuth security scheme, got %v", spec.Components.SecuritySchemes)
+	}
+	if _, ok := spec.Components.SecuritySchemes["apiKeyHeader_X-API-Key"]; !ok {
+		t.Errorf("expected apiKeyHeader_X-API-Key security scheme, got %v", spec.Components.SecuritySchemes)
+	}
+
+	op := spec.Paths["/api/me"].Get
+	if len(op.Security) != 1 {
+		t.Fatalf("expected one security requirement, got %v", op.Security)
+	}
+	if _, ok := op.Security[0]["bearerAuth"]; !ok {
+		t.Errorf("expected bearerAuth requirement, got %v", op.Security)
+	}
+	for _, param := range op.Parameters {
+		if param.In == "header" && param.Name == "Authorization" {
+			t.Error("Authorization header should not be listed as a parameter")
+		}
+	}
+	if len(op.Parameters) != 1 || op.Parameters[0].Name != "fields" {
+		t.Errorf("expected only the fields query parameter, got %+v", op.Parameters)
+	}
+
+	for _, param := range spec.Paths["/partner"].Get.Parameters {
+		if param.Name == "X-API-Key" {
+			t.Error("X-API-Key header should be described by the security scheme")
+		}
+	}
+}
+
+func TestSecurityPropagatesToRegistrar(t *testing.T) {
+	src := `package main
+
+import "github.com/gin-gonic/gin"
+
+func RegisterOrderRoutes(r *gin.RouterGroup) {
+	r.GET("/orders", ListOrders)
+}
+
+func SessionAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, err := c.Cookie("session_id"); err != nil {
+			c.AbortWithStatus(401)
+		}
+	}
+}
+
+func main() {
+	r := gin.Default()
+	api := r.Group("/api")
+	api.Use(SessionAuth())
+	RegisterOrderRoutes(api)
+}
+
+func ListOrders(c *gin.Context) {}
+`
+	p := analyzeSource(t, src)
+
+	route := findRoute(p, "GET", "/api/orders")
+	if route == nil {
+		t.Fatal("expected route GET /api/orders")
+	}
+	if len(route.Security) != 1 || route.Security[0].In != "cookie" || route.Security[0].Name != "session_id" {
+		t.Errorf("expected cookie auth session_id, got %+v", route.Security)
+	}
+}
+
+func TestAuthorizationRolesAndScopes(t *testing.T) {
+	src := `package main
+
+import "github.com/gin-gonic/gin"
+
+func main() {
+	r := gin.Default()
+	admin := r.Group("/admin")
+	admin.Use(RequireRole("admin"))
+	admin.DELETE("/:id", RequireRoles("owner", "superuser"), Delete)
+
+	r.POST("/orders", RequireScopes("orders:write"), CreateOrder)
+	r.PUT("/orders/:id", JWTAuth(), RequireScopes("orders:write"), CreateOrder)
+	r.GET("/reports", Permit([]string{"auditor"}), Reports)
+}
+
+func JWTAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		_ = c.GetHeader("Authorization")
+		c.Next()
+	}
+}
+
+func RequireRole(role string) gin.HandlerFunc { return nil }
+func RequireRoles(roles ...string) gin.HandlerFunc { return nil }
+func RequireScopes(scopes ...string) gin.HandlerFunc { return nil }
+func Permit(roles []string) gin.HandlerFunc { return nil }
+func Delete(c *gin.Context) {}
+func CreateOrder(c *gin.Context) {}
+func Reports(c *gin.Context) {}
+`
+	gen := New().WithAuthorizationRules(AuthorizationRule{Function: "Permit", Kind: AuthorizationRole})
+	p := gen.parser
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "main.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	p.fset = fset
+	p.files = append(p.files, file)
+	if err := p.Analyze(); err != nil {
+		t.Fatalf("analyze error: %v", err)
+	}
+
+	del := findRoute(p, "DELETE", "/admin/:id")
+	if del == nil {
+		t.Fatal("expected route DELETE /admin/:id")
+	}
+	wantRoles := []string{"admin", "owner", "superuser"}
+	if len(del.Roles) != len(wantRoles) {
+		t.Fatalf("roles = %v, want %v", del.Roles, wantRoles)
+	}
+	for i, role := range wantRoles {
+		if del.Roles[i] != role {
+			t.Errorf("roles[%d] = %q, want %q", i, del.Roles[i], role)
+		}
+	}
+
+	reports := findRoute(p, "GET", "/reports")
+	if reports == nil || len(reports.Roles) != 1 || reports.Roles[0] != "auditor" {
+		t.Errorf("expected custom rule to yield role auditor, got %+v", reports)
+	}
+
+	spec, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("generate error: %v", err)
+	}
+
+	if got := spec.Paths["/admin/{id}"].Delete.XRoles; len(got) != 3 {
+		t.Errorf("expected x-roles on DELETE /admin/{id}, got %v", got)
+	}
+
+	// 沒有 token URL 時 oauth2 scheme 不合法，scope 只輸出為 x-scopes
+	op := spec.Paths["/orders"].Post
+	if len(op.Security) != 0 {
+		t.Errorf("expected no security requirement without a token URL, got %v", op.Security)
+	}
+	if len(op.XScopes) != 1 || op.XScopes[0] != "orders:write" {
+		t.Errorf("expected x-scopes orders:write, got %v", op.XScopes)
+	}
+	if _, ok := spec.Components.SecuritySchemes["oauth2"]; ok {
+		t.Errorf("oauth2 scheme should not be emitted without a token URL, got %v", spec.Components.SecuritySchemes)
+	}
+	var missing int
+	for _, d := range gen.Diagnostics() {
+		if d.Code == DiagMissingTokenURL {
+			missing++
+		}
+	}
+	if missing != 2 {
+		t.Errorf("expected %s diagnostic for both scoped routes, got %v", DiagMissingTokenURL, gen.Diagnostics())
+	}
+
+	gen.WithOAuth2TokenURL("https://auth.example.com/oauth/token")
+	before := len(gen.Diagnostics())
+	spec, err = gen.Generate()
+	if err != nil {
+		t.Fatalf("generate error: %v", err)
+	}
+	if len(gen.Diagnostics()) != before {
+		t.Errorf("Generate should not add diagnostics, got %v", gen.Diagnostics())
+	}
+
+	scheme := spec.Components.SecuritySchemes["oauth2"]
+	if scheme == nil || scheme.Flows == nil || scheme.Flows.ClientCredentials == nil {
+		t.Fatalf("expected oauth2 scheme with client credentials flow, got %+v", scheme)
+	}
+	if url := scheme.Flows.ClientCredentials.TokenURL; url != "https://auth.example.com/oauth/token" {
+		t.Errorf("tokenUrl = %q, want configured URL", url)
+	}
+	if _, ok := scheme.Flows.ClientCredentials.Scopes["orders:write"]; !ok {
+		t.Errorf("expected scope orders:write declared in flow, got %v", scheme.Flows.ClientCredentials.Scopes)
+	}
+
+	op = spec.Paths["/orders"].Post
+	if len(op.Security) != 1 || len(op.XScopes) != 0 {
+		t.Fatalf("expected one oauth2 requirement and no x-scopes, got %v %v", op.Security, op.XScopes)
+	}
+	if scopes := op.Security[0]["oauth2"]; len(scopes) != 1 || scopes[0] != "orders:write" {
+		t.Errorf("expected oauth2 scope orders:write, got %v", op.Security)
+	}
+
+	// bearer 與 oauth2 是不同的 requirement（OR），不能放在同一個物件裡
+	update := spec.Paths["/orders/{id}"].Put
+	if len(update.Security) != 2 || len(update.Security[0]) != 1 || len(update.Security[1]) != 1 {
+		t.Fatalf("expected separate bearerAuth and oauth2 requirements, got %v", update.Security)
+	}
+	if _, ok := update.Security[0]["bearerAuth"]; !ok {
+		t.Errorf("expected bearerAuth requirement first, got %v", update.Security)
+	}
+	if _, ok := update.Security[1]["oauth2"]; !ok {
+		t.Errorf("expected oauth2 requirement second, got %v", update.Security)
+	}
+}
+
+// analyzeSource 是測試 helper，解析單一檔案原始碼並執行 Analyze
+func analyzeSource(t *testing.T, src string) *Parser {
+	t.Helper()
+	p := NewParser()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "main.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	p.fset = fset
+	p.files = append(p.files, file)
+
+	if err := p.Analyze(); err != nil {
+		t.Fatalf("analyze error: %v", err)
+	}
+	return p
+}
+
+// findRoute 是測試 helper，依 method 與 path 尋找路由
+func findRoute(p *Parser, method, path string) *RouteInfo {
+	for _, route := range p.Routes {
+		if route.Method == method && route.Path == path {
+			return route
+		}
+	}
+	return nil
+}
+
+func TestIsCredentialName(t *testing.T) {
+	tests := map[string]bool{
+		"Authorization":     true,
+		"X-API-Key":         true,
+		"api_key":           true,
+		"apiKey":            true,
+		"X-Auth-Token":      true,
+		"X-Access-Token":    true,
+		"access_token":      true,
+		"token":             true,
+		"session_id":        true,
+		"sid":               true,
+		"JSESSIONID":        true,
+		"author":            false,
+		"X-Idempotency-Key": false,
+		"sort_key":          false,
+		"page_token":        false,
+		"nextPageToken":     false,
+		"X-Request-ID":      false,
+	}
+	for name, want := range tests {
+		if got := isCredentialName(name); got != want {
+			t.Errorf("isCredentialName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestFindFuncDeclAmbiguousSuffix(t *testing.T) {
+	p := analyzeFiles(t, map[string]string{
+		"jwt/auth.go": `package jwt
+
+func Auth() {}
+func Verify() {}
+`,
+		"apikey/auth.go": `package apikey
+
+func Auth() {}
+`,
+	})
+
+	if fn := p.findFuncDecl("jwt.Auth"); fn == nil {
+		t.Error("expected exact match for jwt.Auth")
+	}
+	if fn := p.findFuncDecl("middleware.Auth"); fn != nil {
+		t.Errorf("expected nil for ambiguous suffix match, got %s", fn.Name.Name)
+	}
+	if fn := p.findFuncDecl("auth.Verify"); fn == nil {
+		t.Error("expected unique suffix match for Verify")
+	}
+}
@@ -0,0 +1,187 @@
+package swaggo
+
+import (
+	"go/ast"
+	"slices"
+	"strings"
+)
+
+// factorySpecialization 工廠在某個呼叫點的特化結果
+type factorySpecialization struct {
+	factory   *ClosureFactory
+	name      string            // 特化後的 handler 名稱，例如 main.MakeCRUDList[User]
+	labels    []string          // 特化用的型別簡稱，例如 [User]
+	typeSubst map[string]string // 型別參數 → 實際型別
+	argTypes  map[string]string // 工廠參數名 → 實際型別
+}
+
+// specializeFactoryCall 依呼叫端的型別引數與引數特化工廠，例如：
+//
+//	MakeCRUDList[User]()     → T = User
+//	MakeHandler(&Order{})    → model 的型別為 Order
+//
+// 無法從呼叫端取得任何型別資訊時回傳 nil，沿用以工廠名稱註冊的 handler
+func (p *Parser) specializeFactoryCall(call *ast.CallExpr, currentPkg string) *factorySpecialization {
+	fun := call.Fun
+	var typeArgs []ast.Expr
+	switch f := fun.(type) {
+	case *ast.IndexExpr:
+		fun, typeArgs = f.X, []ast.Expr{f.Index}
+	case *ast.IndexListExpr:
+		fun, typeArgs = f.X, f.Indices
+	}
+
+	var factoryName string
+	switch f := fun.(type) {
+	case *ast.Ident:
+		factoryName = currentPkg + "." + f.Name
+	case *ast.SelectorExpr:
+		if pkg, ok := f.X.(*ast.Ident); ok {
+			factoryName = pkg.Name + "." + f.Sel.Name
+		}
+	}
+	factory, ok := p.closureFactories[factoryName]
+	if !ok {
+		return nil
+	}
+	if _, typed := p.typedAdapters[factoryName]; typed {
+		return nil
+	}
+
+	spec := &factorySpecialization{
+		factory:   factory,
+		typeSubst: make(map[string]string),
+		argTypes:  make(map[string]string),
+	}
+	var labels []string
+
+	typeParams := fieldNames(factory.FuncDecl.Type.TypeParams)
+	for i, tp := range typeParams {
+		if i < len(typeArgs) {
+			spec.typeSubst[tp] = p.typeToString(typeArgs[i])
+		}
+	}
+
+	if params := factory.FuncDecl.Type.Params; params != nil {
+		i := 0
+		for _, field := range params.List {
+			paramType := p.typeToString(field.Type)
+			for _, name := range field.Names {
+				if i >= len(call.Args) {
+					break
+				}
+				argType := p.argTypeName(call.Args[i])
+				i++
+				if argType == "" {
+					continue
+				}
+				spec.argTypes[name.Name] = argType
+				// 由引數推導型別參數，例如 func MakeHandler[T any](model *T) 搭配 MakeHandler(&User{})
+				if tp := strings.TrimLeft(paramType, "*[]"); slices.Contains(typeParams, tp) {
+					if _, explicit := spec.typeSubst[tp]; !explicit {
+						spec.typeSubst[tp] = strings.TrimLeft(argType, "*[]")
+					}
+				}
+				if !slices.Contains(typeParams, strings.TrimLeft(paramType, "*[]")) {
+					labels = append(labels, typeLabel(argType))
+				}
+			}
+		}
+	}
+
+	if len(spec.typeSubst) == 0 && len(spec.argTypes) == 0 {
+		return nil
+	}
+
+	var typeLabels []string
+	for _, tp := range typeParams {
+		if sub, ok := spec.typeSubst[tp]; ok {
+			typeLabels = append(typeLabels, typeLabel(sub))
+		}
+	}
+	spec.labels = append(typeLabels, labels...)
+	spec.name = factory.FullName + "[" + strings.Join(spec.labels, ",") + "]"
+	return spec
+}
+
+// argTypeName 取得工廠引數的型別：&User{}、User{}、new(User)，或型別已知的變數
+func (p *Parser) argTypeName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.UnaryExpr:
+		return p.argTypeName(e.X)
+	case *ast.CompositeLit:
+		if e.Type != nil {
+			return p.typeToString(e.Type)
+		}
+	case *ast.CallExpr:
+		if ident, ok := e.Fun.(*ast.Ident); ok && ident.Name == "new" && len(e.Args) == 1 {
+			return p.typeToString(e.Args[0])
+		}
+	case *ast.Ident:
+		varType := strings.TrimPrefix(p.findVariableType(e.Name), "*")
+		if varType != e.Name && p.findType(strings.TrimPrefix(varType, "[]")) != nil {
+			return varType
+		}
+	}
+	return ""
+}
+
+// typeLabel 特化名稱中使用的型別簡稱，例如 *models.User → User
+func typeLabel(typeName string) string {
+	typeName = strings.TrimLeft(typeName, "*[]")
+	return typeName[strings.LastIndex(typeName, ".")+1:]
+}
+
+func fieldNames(fields *ast.FieldList) []string {
+	if fields == nil {
+		return nil
+	}
+	var names []string
+	for _, field := range fields.List {
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+	}
+	return names
+}
+
+// registerSpecializedHandlers 為每個可特化的工廠呼叫點註冊獨立的 handler
+func (p *Parser) registerSpecializedHandlers() {
+	if len(p.closureFactories) == 0 {
+		return
+	}
+
+	for _, file := range p.files {
+		pkgName := file.Name.Name
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			spec := p.specializeFactoryCall(call, pkgName)
+			if spec == nil {
+				return true
+			}
+			if _, exists := p.Handlers[spec.name]; exists {
+				return true
+			}
+
+			factory := spec.factory
+			handler := &HandlerInfo{
+				Name:      factory.Name + "_" + strings.Join(spec.labels, "_"),
+				FullName:  spec.name,
+				Package:   factory.Package,
+				Responses: make(map[int]*ResponseInfo),
+				pos:       factory.Closure.Pos(),
+			}
+			p.extractDocComment(factory.FuncDecl.Doc, handler)
+
+			p.typeSubst = spec.typeSubst
+			p.analyzeClosureBody(factory.Closure, handler, spec.argTypes)
+			p.typeSubst = nil
+
+			p.Handlers[spec.name] = handler
+			return true
+		})
+	}
+}
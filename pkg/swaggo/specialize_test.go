@@ -0,0 +1,94 @@
+package swaggo
+
+import (
+	"testing"
+)
+
+func TestFactorySpecialization(t *testing.T) {
+	src := `package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type User struct {
+	ID int ` + "`json:\"id\"`" + `
+}
+
+type Order struct {
+	ID int ` + "`json:\"id\"`" + `
+}
+
+// MakeCRUDList 列出資源
+func MakeCRUDList[T any]() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var items []T
+		c.JSON(http.StatusOK, items)
+	}
+}
+
+func MakeCreate(model interface{}) func(*gin.Context) {
+	return func(c *gin.Context) {
+		c.ShouldBindJSON(model)
+		c.JSON(http.StatusCreated, model)
+	}
+}
+
+func health(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+func MakeHealth() gin.HandlerFunc {
+	return health
+}
+
+func main() {
+	r := gin.Default()
+	r.GET("/users", MakeCRUDList[User]())
+	r.GET("/orders", MakeCRUDList[Order]())
+	r.POST("/users", MakeCreate(&User{}))
+	r.POST("/orders", MakeCreate(&Order{}))
+	r.GET("/health", MakeHealth())
+}
+`
+	p := analyzeSource(t, src)
+
+	for _, tc := range []struct {
+		method, path, handler, body, resp string
+		code                              int
+		isArray                           bool
+	}{
+		{"GET", "/users", "main.MakeCRUDList[User]", "", "User", 200, true},
+		{"GET", "/orders", "main.MakeCRUDList[Order]", "", "Order", 200, true},
+		{"POST", "/users", "main.MakeCreate[User]", "User", "User", 201, false},
+		{"POST", "/orders", "main.MakeCreate[Order]", "Order", "Order", 201, false},
+	} {
+		route := findRoute(p, tc.method, tc.path)
+		if route == nil || route.Handler == nil {
+			t.Errorf("%s %s: expected route with handler", tc.method, tc.path)
+			continue
+		}
+		if route.HandlerName != tc.handler {
+			t.Errorf("%s %s: handler = %q, want %q", tc.method, tc.path, route.HandlerName, tc.handler)
+		}
+		if tc.body != "" && (route.Handler.RequestBody == nil || route.Handler.RequestBody.Name != tc.body) {
+			t.Errorf("%s %s: expected %s body, got %+v", tc.method, tc.path, tc.body, route.Handler.RequestBody)
+		}
+		resp := route.Handler.Responses[tc.code]
+		if resp == nil || resp.Type == nil || resp.Type.Name != tc.resp || resp.IsArray != tc.isArray {
+			t.Errorf("%s %s: expected %d %s response (array=%v), got %+v", tc.method, tc.path, tc.code, tc.resp, tc.isArray, resp)
+		}
+	}
+
+	list := findRoute(p, "GET", "/users")
+	if list != nil && list.Handler != nil && list.Handler.Summary != "MakeCRUDList 列出資源" {
+		t.Errorf("expected summary from factory doc, got %q", list.Handler.Summary)
+	}
+
+	health := findRoute(p, "GET", "/health")
+	if health == nil || health.Handler == nil || health.Handler.Responses[200] == nil {
+		t.Errorf("expected factory returning a named handler to be analyzed, got %+v", health)
+	}
+}
@@ -0,0 +1,94 @@
+package swaggo
+
+import (
+	"go/token"
+	"slices"
+	"strings"
+)
+
+// strictCodes strict 模式下視為失敗的 diagnostic
+var strictCodes = map[string]bool{
+	DiagParseError:        true,
+	DiagUnresolvedHandler: true,
+	DiagDanglingRef:       true,
+}
+
+// routeOperation 路由與其產生的 operation
+type routeOperation struct {
+	route *RouteInfo
+	op    *Operation
+}
+
+// checkSchemaRefs 記錄指向 components 中不存在 schema 的 $ref
+func (g *Generator) checkSchemaRefs(spec *OpenAPI, operations []routeOperation) {
+	p := g.parser
+	for _, ro := range operations {
+		pos := ro.route.pos
+		if ro.route.Handler != nil && ro.route.Handler.pos.IsValid() {
+			pos = ro.route.Handler.pos
+		}
+		for _, name := range danglingRefs(spec, operationSchemas(ro.op)) {
+			p.addRouteDiagnostic(ro.route, DiagDanglingRef, pos, "schema %s is referenced but not defined", name)
+		}
+	}
+	for _, name := range sortedKeys(spec.Components.Schemas) {
+		for _, ref := range danglingRefs(spec, []*Schema{spec.Components.Schemas[name]}) {
+			p.addDiagnostic(DiagDanglingRef, token.NoPos, "schema %s is referenced by %s but not defined", ref, name)
+		}
+	}
+}
+
+// operationSchemas 收集 operation 中參數、request body 與回應的 schema
+func operationSchemas(op *Operation) []*Schema {
+	var schemas []*Schema
+	for _, param := range op.Parameters {
+		schemas = append(schemas, param.Schema)
+	}
+	if op.RequestBody != nil {
+		for _, ct := range sortedKeys(op.RequestBody.Content) {
+			schemas = append(schemas, op.RequestBody.Content[ct].Schema)
+		}
+	}
+	for _, code := range sortedKeys(op.Responses) {
+		resp := op.Responses[code]
+		for _, ct := range sortedKeys(resp.Content) {
+			schemas = append(schemas, resp.Content[ct].Schema)
+		}
+	}
+	return schemas
+}
+
+// danglingRefs 遞迴檢查 schema，回傳 components 中找不到的 schema 名稱
+func danglingRefs(spec *OpenAPI, schemas []*Schema) []string {
+	var missing []string
+	var walk func(s *Schema)
+	walk = func(s *Schema) {
+		if s == nil {
+			return
+		}
+		if name, ok := strings.CutPrefix(s.Ref, "#/components/schemas/"); ok {
+			if _, defined := spec.Components.Schemas[name]; !defined && !slices.Contains(missing, name) {
+				missing = append(missing, name)
+			}
+		}
+		walk(s.Items)
+		for _, key := range sortedKeys(s.Properties) {
+			walk(s.Properties[key])
+		}
+	}
+	for _, s := range schemas {
+		walk(s)
+	}
+	return missing
+}
+
+// strictProblems 回傳 strict 模式下導致失敗的 diagnostic
+func (g *Generator) strictProblems() []Diagnostic {
+	var problems []Diagnostic
+	for _, d := range g.parser.Diagnostics {
+		if strictCodes[d.Code] {
+			problems = append(problems, d)
+		}
+	}
+	return problems
+}
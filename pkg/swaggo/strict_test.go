@@ -0,0 +1,107 @@
+package swaggo
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+var strictProject = map[string]string{
+	"go.mod": "module example.com/app\n",
+	"main.go": `package main
+
+import "github.com/gin-gonic/gin"
+
+func main() {
+	r := gin.Default()
+	r.POST("/orders", CreateOrder)
+	r.GET("/missing", handlers.Missing)
+}
+
+func CreateOrder(c *gin.Context) {
+	var req dto.CreateOrderRequest
+	c.ShouldBindJSON(&req)
+	c.JSON(201, nil)
+}
+`,
+	"broken/broken.go": "package broken\n\nfunc Broken( {\n",
+}
+
+func TestParseDirReportsParseErrors(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, strictProject)
+
+	p := NewParser()
+	p.loadWorkspace(root)
+	err := p.ParseDir(root)
+
+	var perrs ParseErrors
+	if !errors.As(err, &perrs) || len(perrs) != 1 {
+		t.Fatalf("expected one ParseError, got %v", err)
+	}
+	perr := perrs[0]
+	if perr.Package != "example.com/app/broken" {
+		t.Errorf("package = %q, want example.com/app/broken", perr.Package)
+	}
+	if filepath.Base(perr.Position.Filename) != "broken.go" || perr.Position.Line != 3 {
+		t.Errorf("position = %v, want broken.go:3", perr.Position)
+	}
+
+	// 其他 package 的解析結果仍保留
+	if err := p.Analyze(); err != nil {
+		t.Fatal(err)
+	}
+	if findRoute(p, "POST", "/orders") == nil {
+		t.Errorf("routes of valid packages should be kept, got %v", p.Routes)
+	}
+}
+
+func TestStrictMode(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, strictProject)
+
+	// 寬鬆模式：語法錯誤記錄為 diagnostic，仍然產生文件
+	gen := New()
+	if err := gen.ParseSource(root); err != nil {
+		t.Fatalf("lenient parse should succeed, got %v", err)
+	}
+	if _, err := gen.Generate(); err != nil {
+		t.Fatalf("lenient generate should succeed, got %v", err)
+	}
+	codes := make(map[string]bool)
+	for _, d := range gen.Diagnostics() {
+		codes[d.Code] = true
+	}
+	for _, code := range []string{DiagParseError, DiagUnresolvedHandler, DiagDanglingRef} {
+		if !codes[code] {
+			t.Errorf("missing %s diagnostic: %v", code, gen.Diagnostics())
+		}
+	}
+
+	// strict 模式：語法錯誤直接回傳
+	gen = New()
+	gen.SetStrict(true)
+	if err := gen.ParseSource(root); !IsParseError(err) {
+		t.Fatalf("strict parse should return ParseErrors, got %v", err)
+	}
+
+	// strict 模式：handler 無法解析與 dangling $ref 使產生失敗
+	writeFiles(t, root, map[string]string{"broken/broken.go": "package broken\n"})
+	gen = New()
+	gen.SetStrict(true)
+	if err := gen.ParseSource(root); err != nil {
+		t.Fatal(err)
+	}
+	_, err := gen.Generate()
+	var strictErr *StrictError
+	if !errors.As(err, &strictErr) {
+		t.Fatalf("expected StrictError, got %v", err)
+	}
+	got := make(map[string]string)
+	for _, d := range strictErr.Diagnostics {
+		got[d.Code] = d.Route
+	}
+	if got[DiagUnresolvedHandler] != "GET /missing" || got[DiagDanglingRef] != "POST /orders" {
+		t.Errorf("strict problems = %v", strictErr.Diagnostics)
+	}
+}
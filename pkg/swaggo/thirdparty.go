@@ -0,0 +1,177 @@
+package swaggo
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// 第三方 registrar 路由的處理方式
+const (
+	ThirdPartyDocument = "document" // 與一般路由一樣輸出
+	ThirdPartyInternal = "internal" // 輸出並標上 internal tag
+	ThirdPartyExclude  = "exclude"  // 不輸出
+)
+
+// ThirdPartyRoute 第三方 registrar 註冊的單一路由，Path 相對於 registrar 的 prefix
+type ThirdPartyRoute struct {
+	Method      string
+	Path        string
+	Summary     string
+	ContentType string // 空字串表示 application/json
+}
+
+// ThirdPartyRegistrar 在外部套件內註冊路由的函數，swaggo 不會解析其原始碼，改以已知的路由表描述
+// Function 可為 pkg.Func（pprof.Register）或 pkg.Type.Method（ginprometheus.Prometheus.Use），
+// 後者的型別由 p := pkg.NewType(...) 推斷
+type ThirdPartyRegistrar struct {
+	Function  string
+	RouterArg int    // router 所在的引數位置，-1 表示掛在根 router
+	PrefixArg int    // 可覆寫 Prefix 的字串引數位置，0 表示沒有
+	Prefix    string // 預設 prefix
+	Tag       string
+	Routes    []ThirdPartyRoute
+}
+
+// DefaultThirdPartyRegistrars 內建的第三方 registrar
+func DefaultThirdPartyRegistrars() []ThirdPartyRegistrar {
+	pprofRoutes := []ThirdPartyRoute{
+		{Method: "GET", Path: "/", Summary: "pprof index", ContentType: "text/html"},
+		{Method: "GET", Path: "/cmdline", Summary: "pprof command line", ContentType: "text/plain"},
+		{Method: "GET", Path: "/profile", Summary: "pprof CPU profile", ContentType: "application/octet-stream"},
+		{Method: "GET", Path: "/symbol", Summary: "pprof symbol lookup", ContentType: "text/plain"},
+		{Method: "POST", Path: "/symbol", Summary: "pprof symbol lookup", ContentType: "text/plain"},
+		{Method: "GET", Path: "/trace", Summary: "pprof execution trace", ContentType: "application/octet-stream"},
+		{Method: "GET", Path: "/allocs", Summary: "pprof allocs profile", ContentType: "application/octet-stream"},
+		{Method: "GET", Path: "/block", Summary: "pprof block profile", ContentType: "application/octet-stream"},
+		{Method: "GET", Path: "/goroutine", Summary: "pprof goroutine profile", ContentType: "application/octet-stream"},
+		{Method: "GET", Path: "/heap", Summary: "pprof heap profile", ContentType: "application/octet-stream"},
+		{Method: "GET", Path: "/mutex", Summary: "pprof mutex profile", ContentType: "application/octet-stream"},
+		{Method: "GET", Path: "/threadcreate", Summary: "pprof threadcreate profile", ContentType: "application/octet-stream"},
+	}
+	metricsRoutes := []ThirdPartyRoute{
+		{Method: "GET", Path: "/metrics", Summary: "Prometheus metrics", ContentType: "text/plain"},
+	}
+
+	return []ThirdPartyRegistrar{
+		// github.com/gin-contrib/pprof
+		{Function: "pprof.Register", PrefixArg: 1, Prefix: "/debug/pprof", Tag: "debug", Routes: pprofRoutes},
+		{Function: "pprof.RouteRegister", PrefixArg: 1, Prefix: "/debug/pprof", Tag: "debug", Routes: pprofRoutes},
+		// github.com/labstack/echo-contrib/pprof
+		{Function: "echopprof.Register", PrefixArg: 1, Prefix: "/debug/pprof", Tag: "debug", Routes: pprofRoutes},
+		// github.com/zsais/go-gin-prometheus
+		{Function: "ginprometheus.Prometheus.Use", Tag: "metrics", Routes: metricsRoutes},
+		// github.com/Depado/ginprom
+		{Function: "ginprom.New", RouterArg: -1, Tag: "metrics", Routes: metricsRoutes},
+		{Function: "ginprom.Use", Tag: "metrics", Routes: metricsRoutes},
+		// github.com/tavsec/gin-healthcheck
+		{Function: "healthcheck.New", Tag: "health", Routes: []ThirdPartyRoute{
+			{Method: "GET", Path: "/healthz", Summary: "Health check"},
+		}},
+	}
+}
+
+// tryAddThirdPartyRoutes 比對第三方 registrar 的呼叫，加入其已知的路由
+func (p *Parser) tryAddThirdPartyRoutes(call *ast.CallExpr, groupPrefixes map[string]string, groupMiddlewares map[string][]*MiddlewareInfo) {
+	if p.thirdPartyMode == ThirdPartyExclude {
+		return
+	}
+	reg := p.matchThirdPartyRegistrar(call)
+	if reg == nil {
+		return
+	}
+
+	var groupPrefix string
+	var middlewares []*MiddlewareInfo
+	if reg.RouterArg >= 0 {
+		if reg.RouterArg >= len(call.Args) {
+			return
+		}
+		router := call.Args[reg.RouterArg]
+		groupPrefix = p.getReceiverPrefix(router, groupPrefixes)
+		middlewares = p.resolveGroupMiddlewares(router, groupMiddlewares)
+	}
+
+	prefix := reg.Prefix
+	if reg.PrefixArg > 0 && reg.PrefixArg < len(call.Args) {
+		if custom := p.extractStringArg(call.Args[reg.PrefixArg]); custom != "" {
+			prefix = custom
+		}
+	}
+	prefix = strings.TrimSuffix(prefix, "/")
+
+	tag := reg.Tag
+	if p.thirdPartyMode == ThirdPartyInternal {
+		tag = "internal"
+	}
+
+	pkgAlias := strings.SplitN(reg.Function, ".", 2)[0]
+	for _, tr := range reg.Routes {
+		rc := &RouteCall{Method: tr.Method, Path: prefix + tr.Path}
+		handlerName := reg.Function + ":" + tr.Method + ":" + groupPrefix + rc.Path
+		route := p.newRouteInfo(rc, groupPrefix, handlerName, middlewares)
+		if p.routeExists(route.Method, route.Path) {
+			continue
+		}
+
+		if _, exists := p.Handlers[handlerName]; !exists {
+			handler := &HandlerInfo{
+				Name:      thirdPartyOperationName(tr.Method, route.Path),
+				FullName:  handlerName,
+				Package:   pkgAlias,
+				Receiver:  pkgAlias,
+				Summary:   tr.Summary,
+				Responses: make(map[int]*ResponseInfo),
+			}
+			if tag != "" {
+				handler.Tags = []string{tag}
+			}
+			handler.Responses[200] = &ResponseInfo{
+				StatusCode:  200,
+				ContentType: tr.ContentType,
+				Type:        contentTypeSchema(tr.ContentType),
+			}
+			p.Handlers[handlerName] = handler
+		}
+		route.pos = call.Pos()
+		p.Routes = append(p.Routes, route)
+	}
+}
+
+// matchThirdPartyRegistrar 以 pkg.Func 或 pkg.Type.Method 比對第三方 registrar
+func (p *Parser) matchThirdPartyRegistrar(call *ast.CallExpr) *ThirdPartyRegistrar {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+
+	name := ident.Name + "." + sel.Sel.Name
+	for i := range p.thirdPartyRegistrars {
+		reg := &p.thirdPartyRegistrars[i]
+		if reg.Function == name {
+			return reg
+		}
+		// pkg.Type.Method 只在方法名稱相符時才推斷變數型別
+		if strings.Count(reg.Function, ".") == 2 && strings.HasSuffix(reg.Function, "."+sel.Sel.Name) {
+			if typeName, _ := p.instanceType(ident); typeName+"."+sel.Sel.Name == reg.Function {
+				return reg
+			}
+		}
+	}
+	return nil
+}
+
+// thirdPartyOperationName 由 method 與 path 產生 operation 名稱，例如 GET /debug/pprof/heap → get_debug_pprof_heap
+func thirdPartyOperationName(method, path string) string {
+	var parts []string
+	for _, part := range strings.Split(path, "/") {
+		part = strings.Trim(part, ":*")
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.ToLower(method) + "_" + strings.Join(parts, "_")
+}
@@ -0,0 +1,107 @@
+package swaggo
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+const thirdPartySource = `package main
+
+import (
+	"github.com/gin-contrib/pprof"
+	"github.com/gin-gonic/gin"
+	healthcheck "github.com/tavsec/gin-healthcheck"
+	"github.com/tavsec/gin-healthcheck/config"
+	ginprometheus "github.com/zsais/go-gin-prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+
+	"example.com/app/metrics"
+)
+
+// customMetrics 同名變數的建構函數不同，不能影響 main 內 prom 的型別
+func customMetrics() {
+	prom := metrics.NewCollector()
+	_ = prom
+}
+
+func main() {
+	r := gin.Default()
+	pprof.Register(r)
+
+	admin := r.Group("/admin")
+	pprof.Register(admin, "/debug")
+
+	prom := ginprometheus.NewPrometheus("gin")
+	prom.Use(r)
+
+	healthcheck.New(r, config.DefaultConfig(), nil)
+
+	r.GET("/internal/metrics", gin.WrapH(promhttp.Handler()))
+	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+}
+`
+
+func analyzeThirdParty(t *testing.T, mode string) *Parser {
+	t.Helper()
+	p := NewParser()
+	p.thirdPartyMode = mode
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "main.go", thirdPartySource, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	p.fset = fset
+	p.files = append(p.files, file)
+	if err := p.Analyze(); err != nil {
+		t.Fatalf("analyze error: %v", err)
+	}
+	return p
+}
+
+func TestThirdPartyRegistrars(t *testing.T) {
+	p := analyzeThirdParty(t, ThirdPartyDocument)
+
+	for _, key := range []string{
+		"GET /debug/pprof/",
+		"GET /debug/pprof/heap",
+		"POST /debug/pprof/symbol",
+		"GET /admin/debug/heap",
+		"GET /metrics",
+		"GET /healthz",
+		"GET /internal/metrics",
+		"GET /swagger/*any",
+	} {
+		method, path, _ := strings.Cut(key, " ")
+		route := findRoute(p, method, path)
+		if route == nil || route.Handler == nil {
+			t.Errorf("expected third-party route %s", key)
+		}
+	}
+
+	heap := findRoute(p, "GET", "/debug/pprof/heap")
+	if heap != nil && heap.Handler != nil && (len(heap.Handler.Tags) != 1 || heap.Handler.Tags[0] != "debug") {
+		t.Errorf("expected debug tag, got %v", heap.Handler.Tags)
+	}
+
+	internal := analyzeThirdParty(t, ThirdPartyInternal)
+	metrics := findRoute(internal, "GET", "/metrics")
+	if metrics == nil || metrics.Handler == nil || len(metrics.Handler.Tags) != 1 || metrics.Handler.Tags[0] != "internal" {
+		t.Errorf("expected internal tag in internal mode, got %+v", metrics)
+	}
+	swagger := findRoute(internal, "GET", "/swagger/*any")
+	if swagger == nil || swagger.Handler == nil || len(swagger.Handler.Tags) != 1 || swagger.Handler.Tags[0] != "internal" {
+		t.Errorf("expected internal tag on known handler route in internal mode, got %+v", swagger)
+	}
+
+	excluded := analyzeThirdParty(t, ThirdPartyExclude)
+	if len(excluded.Routes) != 0 {
+		for _, route := range excluded.Routes {
+			t.Logf("  found: %s %s -> %s", route.Method, route.Path, route.HandlerName)
+		}
+		t.Errorf("expected no routes in exclude mode, got %d", len(excluded.Routes))
+	}
+}
@@ -0,0 +1,244 @@
+package swaggo
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// collectTypedAdapters 收集泛型 handler adapter，例如：
+//
+//	func Typed[Req, Resp any](fn func(context.Context, Req) (Resp, error)) gin.HandlerFunc
+//
+// 條件：有型別參數、回傳框架的 handler 型別、且第一個參數是函數
+func (p *Parser) collectTypedAdapters() {
+	for _, file := range p.files {
+		pkgName := file.Name.Name
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || fn.Type.TypeParams == nil || fn.Type.Params == nil || len(fn.Type.Params.List) == 0 {
+				continue
+			}
+			if _, ok := fn.Type.Params.List[0].Type.(*ast.FuncType); !ok {
+				continue
+			}
+			if !p.returnsGinHandlerFunc(fn.Type.Results) {
+				continue
+			}
+			p.typedAdapters[pkgName+"."+fn.Name.Name] = fn
+		}
+	}
+}
+
+// typedAdapterName 若 call 呼叫的是泛型 adapter（可含明確的型別引數），回傳 adapter 的完整名稱
+func (p *Parser) typedAdapterName(call *ast.CallExpr, currentPkg string) string {
+	adapterName := calledFuncName(call, currentPkg)
+	if _, ok := p.typedAdapters[adapterName]; !ok {
+		return ""
+	}
+	return adapterName
+}
+
+// typedAdapterTarget 若 call 是 Typed(h.GetUser) 或 Typed[Req, Resp](h.GetUser)，回傳被包裝函數的名稱
+func (p *Parser) typedAdapterTarget(call *ast.CallExpr, currentPkg string) string {
+	if len(call.Args) == 0 || p.typedAdapterName(call, currentPkg) == "" {
+		return ""
+	}
+	switch call.Args[0].(type) {
+	case *ast.Ident, *ast.SelectorExpr:
+		return p.resolveHandlerName(call.Args[0], currentPkg)
+	}
+	return ""
+}
+
+// registerTypedHandlers 為被泛型 adapter 包裝的函數建立 handler，
+// request 來自函數的參數型別，response 來自回傳型別
+func (p *Parser) registerTypedHandlers() {
+	if len(p.typedAdapters) == 0 {
+		return
+	}
+
+	done := make(map[string]bool)
+	for _, file := range p.files {
+		pkgName := file.Name.Name
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			target := p.typedAdapterTarget(call, pkgName)
+			if target == "" {
+				return true
+			}
+
+			fn := p.findFuncDecl(target)
+			if fn == nil {
+				return true
+			}
+			fullName := p.buildFuncFullName(fn, p.funcDeclPackage(fn, pkgName))
+			if done[fullName] {
+				return true
+			}
+			done[fullName] = true
+
+			handler := p.Handlers[fullName]
+			if handler == nil {
+				handler = &HandlerInfo{
+					Name:      fn.Name.Name,
+					FullName:  fullName,
+					Package:   p.funcDeclPackage(fn, pkgName),
+					FilePath:  p.fset.Position(fn.Pos()).Filename,
+					Responses: make(map[int]*ResponseInfo),
+					pos:       fn.Pos(),
+				}
+				if fn.Recv != nil && len(fn.Recv.List) > 0 {
+					handler.Receiver = p.extractReceiverType(fn.Recv.List[0].Type)
+				}
+				p.extractDocComment(fn.Doc, handler)
+				p.Handlers[fullName] = handler
+			}
+
+			p.applyTypedSignature(fn.Type, handler)
+			p.mergeAdapterResponses(call, pkgName, handler)
+			return true
+		})
+	}
+}
+
+// funcDeclPackage 取得函數宣告所在的 package 名稱
+func (p *Parser) funcDeclPackage(fn *ast.FuncDecl, fallback string) string {
+	for _, file := range p.files {
+		if file.Pos() <= fn.Pos() && fn.End() <= file.End() {
+			return file.Name.Name
+		}
+	}
+	return fallback
+}
+
+// applyTypedSignature 由 func(ctx context.Context, req Req) (Resp, error) 推導 request 與 200 回應
+// Req 的欄位依 struct tag 拆分：uri/path/param → path、form/query → query、header → header，其餘為 body
+func (p *Parser) applyTypedSignature(fn *ast.FuncType, handler *HandlerInfo) {
+	if fn.Params != nil {
+		for _, param := range fn.Params.List {
+			typeName := strings.TrimPrefix(p.typeToString(param.Type), "*")
+			if strings.HasSuffix(typeName, "Context") {
+				continue
+			}
+			p.splitTypedRequest(typeName, handler)
+			break
+		}
+	}
+
+	if fn.Results != nil {
+		for _, result := range fn.Results.List {
+			if p.typeToString(result.Type) == "error" {
+				continue
+			}
+			resp := &ResponseInfo{StatusCode: 200}
+			resp.Type, resp.IsArray = p.typedResultType(result.Type)
+			handler.Responses[200] = resp
+			break
+		}
+	}
+}
+
+// splitTypedRequest 把 Req 的欄位拆成參數與 request body；
+// 找不到型別（例如 struct{}、any 或未解析的 package）時不輸出 request body，避免指向不存在的 schema
+func (p *Parser) splitTypedRequest(typeName string, handler *HandlerInfo) {
+	typeInfo := p.findType(typeName)
+	if typeInfo == nil {
+		return
+	}
+
+	var bodyFields []*FieldInfo
+	for _, field := range typeInfo.Fields {
+		in, name := typedFieldLocation(field)
+		if in == "body" {
+			bodyFields = append(bodyFields, field)
+			continue
+		}
+		if name == "-" {
+			continue
+		}
+		handler.Parameters = append(handler.Parameters, &ParameterInfo{
+			Name:     name,
+			Type:     field.Type,
+			In:       in,
+			Required: in == "path" || field.Required,
+			Comment:  field.Comment,
+		})
+	}
+
+	switch {
+	case len(bodyFields) == 0:
+		// 沒有 body 欄位，例如只有 uri / form 欄位或空的 struct
+	case len(bodyFields) == len(typeInfo.Fields):
+		handler.RequestBody = typeInfo
+	case len(bodyFields) > 0:
+		// 只有部分欄位在 body，以匿名 schema 描述
+		handler.RequestBody = &TypeInfo{Kind: "struct", Fields: bodyFields}
+	}
+}
+
+// typedFieldLocation 依 struct tag 判斷欄位來源，回傳位置與參數名
+func typedFieldLocation(field *FieldInfo) (string, string) {
+	for _, loc := range []struct{ tag, in string }{
+		{"uri", "path"}, {"path", "path"}, {"param", "path"},
+		{"form", "query"}, {"query", "query"},
+		{"header", "header"},
+	} {
+		if tag, ok := field.Tags[loc.tag]; ok {
+			name := strings.Split(tag, ",")[0]
+			if name == "" {
+				name = field.Name
+			}
+			return loc.in, name
+		}
+	}
+	return "body", field.JSONName
+}
+
+func (p *Parser) typedResultType(expr ast.Expr) (*TypeInfo, bool) {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if arr, ok := expr.(*ast.ArrayType); ok {
+		elem, _ := p.typedResultType(arr.Elt)
+		return elem, true
+	}
+
+	typeName := p.typeToString(expr)
+	if typeInfo := p.findType(typeName); typeInfo != nil {
+		return typeInfo, false
+	}
+	if _, ok := expr.(*ast.MapType); ok {
+		return &TypeInfo{Kind: "map"}, false
+	}
+	return &TypeInfo{Name: typeName, Kind: "primitive"}, false
+}
+
+// mergeAdapterResponses 沿用 adapter 閉包中的錯誤回應，例如 c.JSON(http.StatusBadRequest, ErrorResponse{})
+// 型別為 adapter 型別參數的回應（例如 c.JSON(200, resp)）會被略過
+func (p *Parser) mergeAdapterResponses(call *ast.CallExpr, pkgName string, handler *HandlerInfo) {
+	adapterName := p.typedAdapterName(call, pkgName)
+	adapter, ok := p.Handlers[adapterName]
+	if !ok {
+		return
+	}
+
+	typeParams := make(map[string]bool)
+	for _, field := range p.typedAdapters[adapterName].Type.TypeParams.List {
+		for _, name := range field.Names {
+			typeParams[name.Name] = true
+		}
+	}
+
+	for code, resp := range adapter.Responses {
+		if _, exists := handler.Responses[code]; exists {
+			continue
+		}
+		if resp.Type != nil && typeParams[resp.Type.Name] {
+			continue
+		}
+		handler.Responses[code] = resp
+	}
+}
@@ -0,0 +1,201 @@
+package swaggo
+
+import (
+	"testing"
+)
+
+func TestTypedGenericAdapter(t *testing.T) {
+	src := `package main
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type User struct {
+	ID   int    ` + "`json:\"id\"`" + `
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type GetUserRequest struct {
+	ID     int    ` + "`uri:\"id\"`" + `
+	Fields string ` + "`form:\"fields\"`" + `
+}
+
+type UpdateUserRequest struct {
+	ID    int    ` + "`uri:\"id\"`" + `
+	Trace string ` + "`header:\"X-Trace-ID\"`" + `
+	Name  string ` + "`json:\"name\"`" + `
+}
+
+type CreateUserRequest struct {
+	Name string ` + "`json:\"name\" binding:\"required\"`" + `
+}
+
+type ErrorResponse struct {
+	Message string ` + "`json:\"message\"`" + `
+}
+
+func Typed[Req, Resp any](fn func(context.Context, Req) (Resp, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req Req
+		if err := c.ShouldBind(&req); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{})
+			return
+		}
+		resp, err := fn(c, req)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{})
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+type UserHandler struct{}
+
+// GetUser 取得使用者
+func (h *UserHandler) GetUser(ctx context.Context, req GetUserRequest) (*User, error) {
+	return &User{}, nil
+}
+
+func (h *UserHandler) UpdateUser(ctx context.Context, req UpdateUserRequest) (User, error) {
+	return User{}, nil
+}
+
+func (h *UserHandler) CreateUser(ctx context.Context, req *CreateUserRequest) ([]User, error) {
+	return nil, nil
+}
+
+func main() {
+	r := gin.Default()
+	h := &UserHandler{}
+	api := r.Group("/api")
+	api.GET("/users/:id", Typed(h.GetUser))
+	api.PUT("/users/:id", Typed(h.UpdateUser))
+	api.POST("/users", Typed[*CreateUserRequest, []User](h.CreateUser))
+}
+`
+	p := analyzeSource(t, src)
+
+	get := findRoute(p, "GET", "/api/users/:id")
+	if get == nil || get.Handler == nil {
+		for _, route := range p.Routes {
+			t.Logf("  found: %s %s -> %s", route.Method, route.Path, route.HandlerName)
+		}
+		t.Fatal("expected GET /api/users/:id with handler")
+	}
+	if get.HandlerName != "main.UserHandler.GetUser" {
+		t.Errorf("handler = %q, want wrapped function", get.HandlerName)
+	}
+	if get.Handler.Summary != "GetUser 取得使用者" {
+		t.Errorf("expected summary from wrapped function doc, got %q", get.Handler.Summary)
+	}
+	params := make(map[string]string)
+	for _, param := range get.Handler.Parameters {
+		params[param.In+":"+param.Name] = param.Type
+	}
+	if params["path:id"] != "int" || params["query:fields"] != "string" {
+		t.Errorf("expected path id and query fields, got %+v", params)
+	}
+	if get.Handler.RequestBody != nil {
+		t.Errorf("expected no body for GET, got %+v", get.Handler.RequestBody)
+	}
+	if resp := get.Handler.Responses[200]; resp == nil || resp.Type == nil || resp.Type.Name != "User" || resp.IsArray {
+		t.Errorf("expected 200 User response, got %+v", get.Handler.Responses[200])
+	}
+	for _, code := range []int{400, 500} {
+		if resp := get.Handler.Responses[code]; resp == nil || resp.Type == nil || resp.Type.Name != "ErrorResponse" {
+			t.Errorf("expected %d ErrorResponse from adapter, got %+v", code, resp)
+		}
+	}
+
+	update := findRoute(p, "PUT", "/api/users/:id")
+	if update == nil || update.Handler == nil {
+		t.Fatal("expected PUT /api/users/:id with handler")
+	}
+	var hasHeader bool
+	for _, param := range update.Handler.Parameters {
+		if param.In == "header" && param.Name == "X-Trace-ID" {
+			hasHeader = true
+		}
+	}
+	if !hasHeader {
+		t.Errorf("expected header param X-Trace-ID, got %+v", update.Handler.Parameters)
+	}
+	body := update.Handler.RequestBody
+	if body == nil || len(body.Fields) != 1 || body.Fields[0].JSONName != "name" {
+		t.Errorf("expected body with only json fields, got %+v", body)
+	}
+
+	create := findRoute(p, "POST", "/api/users")
+	if create == nil || create.Handler == nil {
+		t.Fatal("expected POST /api/users with handler")
+	}
+	if create.Handler.RequestBody == nil || create.Handler.RequestBody.Name != "CreateUserRequest" {
+		t.Errorf("expected CreateUserRequest body, got %+v", create.Handler.RequestBody)
+	}
+	if resp := create.Handler.Responses[200]; resp == nil || !resp.IsArray || resp.Type == nil || resp.Type.Name != "User" {
+		t.Errorf("expected 200 []User response, got %+v", create.Handler.Responses[200])
+	}
+}
+
+func TestTypedAdapterWithoutRequestBody(t *testing.T) {
+	p := analyzeSource(t, `package main
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+type User struct {
+	ID int `+"`json:\"id\"`"+`
+}
+
+type Empty struct{}
+
+func Typed[Req, Resp any](fn func(context.Context, Req) (Resp, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req Req
+		_ = c.ShouldBind(&req)
+		resp, _ := fn(c, req)
+		c.JSON(200, resp)
+	}
+}
+
+func Ping(ctx context.Context, req struct{}) (User, error) { return User{}, nil }
+func Me(ctx context.Context, req Empty) (User, error)      { return User{}, nil }
+func Stats(ctx context.Context, req any) (User, error)     { return User{}, nil }
+
+func main() {
+	r := gin.Default()
+	r.GET("/ping", Typed(Ping))
+	r.GET("/me", Typed(Me))
+	r.GET("/stats", Typed[any, User](Stats))
+}
+`)
+
+	for _, path := range []string{"/ping", "/me", "/stats"} {
+		route := findRoute(p, "GET", path)
+		if route == nil || route.Handler == nil {
+			t.Fatalf("expected GET %s with handler", path)
+		}
+		if body := route.Handler.RequestBody; body != nil {
+			t.Errorf("GET %s: expected no request body, got %+v", path, body)
+		}
+	}
+
+	gen := New()
+	gen.parser = p
+	if _, err := gen.Generate(); err != nil {
+		t.Fatalf("generate error: %v", err)
+	}
+	for _, d := range gen.Diagnostics() {
+		if d.Code == DiagDanglingRef {
+			t.Errorf("unexpected dangling ref: %v", d)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package swaggo
 import (
 	"go/ast"
 	"go/token"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -76,6 +77,26 @@ func (p *Parser) extractStatusCode(expr ast.Expr) int {
 	return 0
 }
 
+// extractHTTPMethodArg 解析 HTTP method 引數：字串字面量或 http.MethodGet、fiber.MethodGet 等常數
+func (p *Parser) extractHTTPMethodArg(expr ast.Expr) string {
+	if sel, ok := expr.(*ast.SelectorExpr); ok {
+		if _, ok := sel.X.(*ast.Ident); ok && strings.HasPrefix(sel.Sel.Name, "Method") && len(sel.Sel.Name) > len("Method") {
+			return strings.ToUpper(strings.TrimPrefix(sel.Sel.Name, "Method"))
+		}
+		return ""
+	}
+	return p.extractStringArg(expr)
+}
+
+// isHeaderGet 判斷 selector 是否為 xxx.Header.Get
+func isHeaderGet(sel *ast.SelectorExpr) bool {
+	if sel.Sel.Name != "Get" {
+		return false
+	}
+	inner, ok := sel.X.(*ast.SelectorExpr)
+	return ok && inner.Sel.Name == "Header"
+}
+
 func parseStructTags(tag string) map[string]string {
 	tags := make(map[string]string)
 
@@ -185,3 +206,64 @@ func shouldSkipHandler(name string) bool {
 	}
 	return false
 }
+
+// convertBracePath 把 {id} 風格的 wildcard 轉成 gin 風格，回傳轉換後的 path 與各參數的 regex 限制
+// {id} → :id、{id:[0-9]+} → :id（pattern ^[0-9]+$）、{path...} → *path，{$} 只代表精確匹配而移除
+func convertBracePath(path string) (string, map[string]string) {
+	var patterns map[string]string
+	var b strings.Builder
+
+	for i := 0; i < len(path); i++ {
+		if path[i] != '{' {
+			b.WriteByte(path[i])
+			continue
+		}
+
+		// regex 內可能還有 {n}，需要計算巢狀深度
+		end, depth := -1, 0
+		for j := i; j < len(path); j++ {
+			if path[j] == '{' {
+				depth++
+			} else if path[j] == '}' {
+				depth--
+				if depth == 0 {
+					end = j
+					break
+				}
+			}
+		}
+		if end < 0 {
+			b.WriteString(path[i:])
+			break
+		}
+
+		name := path[i+1 : end]
+		switch {
+		case name == "$":
+		case strings.HasSuffix(name, "..."):
+			b.WriteString("*" + strings.TrimSuffix(name, "..."))
+		default:
+			if idx := strings.Index(name, ":"); idx >= 0 {
+				if patterns == nil {
+					patterns = make(map[string]string)
+				}
+				patterns[name[:idx]] = "^" + name[idx+1:] + "$"
+				name = name[:idx]
+			}
+			b.WriteString(":" + name)
+		}
+		i = end
+	}
+
+	return b.String(), patterns
+}
+
+// sortedKeys 回傳排序後的 map key，讓輸出順序固定
+func sortedKeys[V any](m map[string]V) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -11,7 +11,8 @@ func TestConvertGinPathToOpenAPI(t *testing.T) {
 	}{
 		{"/users/:id", "/users/{id}"},
 		{"/users/:id/posts/:postId", "/users/{id}/posts/{postId}"},
-		{"/files/*filepath", "/files/{filepath"}, // 實際行為：wildcard 處理有 bug，但不影響使用
+		{"/files/*filepath", "/files/{filepath}"},
+		{"/static/*", "/static/*"},
 		{"/api/v1/items", "/api/v1/items"},
 		{"", ""},
 	}
@@ -0,0 +1,197 @@
+package swaggo
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// 靜態路由與執行期路由的差異種類
+const (
+	MismatchMissingStatic  = "missing-static"   // 執行期有註冊，靜態分析沒有找到
+	MismatchMissingRuntime = "missing-runtime"  // 靜態分析找到，執行期沒有註冊
+	MismatchHandler        = "handler-mismatch" // 路由相同但 handler 不同
+)
+
+// anyMethods gin 的 Any 展開的 method
+var anyMethods = []string{"GET", "POST", "PUT", "PATCH", "HEAD", "OPTIONS", "DELETE", "CONNECT", "TRACE"}
+
+// RuntimeRoute 執行期實際註冊的路由，來自 gin 的 debug 輸出或 Engine.Routes()
+type RuntimeRoute struct {
+	Method  string `json:"method"`
+	Path    string `json:"path"`
+	Handler string `json:"handler"`
+}
+
+// RouteMismatch 靜態分析與執行期路由的一筆差異
+type RouteMismatch struct {
+	Kind           string
+	Method         string
+	Path           string
+	StaticHandler  string
+	RuntimeHandler string
+	Position       token.Position // 靜態路由的註冊位置
+}
+
+func (m RouteMismatch) String() string {
+	var detail string
+	switch m.Kind {
+	case MismatchMissingStatic:
+		detail = fmt.Sprintf("registered at runtime by %s but not found by static analysis", m.RuntimeHandler)
+	case MismatchMissingRuntime:
+		detail = fmt.Sprintf("found by static analysis (%s) but not registered at runtime", m.StaticHandler)
+	case MismatchHandler:
+		detail = fmt.Sprintf("handler differs: static %s, runtime %s", m.StaticHandler, m.RuntimeHandler)
+	}
+	s := fmt.Sprintf("%s %s: %s [%s]", m.Method, m.Path, detail, m.Kind)
+	if m.Position.IsValid() {
+		s = m.Position.String() + ": " + s
+	}
+	return s
+}
+
+// ginDebugRoute 比對 [GIN-debug] GET /x --> pkg.(*H).Get-fm (3 handlers)
+var ginDebugRoute = regexp.MustCompile(`\[GIN-debug\]\s+([A-Z]+)\s+(\S+)\s+-->\s+(\S+)\s+\(\d+ handlers\)`)
+
+// ReadRuntimeRoutes 讀取執行期路由：JSON 陣列（Engine.Routes() 的 method / path / handler）
+// 或 gin debug 模式的輸出，兩者依內容自動判斷
+func ReadRuntimeRoutes(r io.Reader) ([]RuntimeRoute, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// gin debug 輸出也以 [ 開頭，需整份是合法 JSON 才視為 JSON 陣列
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' && json.Valid(trimmed) {
+		var routes []RuntimeRoute
+		if err := json.Unmarshal(trimmed, &routes); err != nil {
+			return nil, fmt.Errorf("parse routes JSON: %w", err)
+		}
+		return routes, nil
+	}
+
+	var routes []RuntimeRoute
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		m := ginDebugRoute.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+		routes = append(routes, RuntimeRoute{Method: m[1], Path: m[2], Handler: m[3]})
+	}
+	return routes, scanner.Err()
+}
+
+type routeKey struct{ method, path string }
+
+// CompareRoutes 比對靜態分析的路由與執行期路由，結果依 path、method 排序
+// Any 路由展開成 gin 註冊的所有 method
+func CompareRoutes(static []*RouteInfo, runtime []RuntimeRoute) []RouteMismatch {
+	staticRoutes := make(map[routeKey]*RouteInfo)
+	for _, route := range static {
+		methods := []string{strings.ToUpper(route.Method)}
+		if strings.EqualFold(route.Method, "Any") {
+			methods = anyMethods
+		}
+		for _, method := range methods {
+			key := routeKey{method, route.Path}
+			if _, exists := staticRoutes[key]; !exists {
+				staticRoutes[key] = route
+			}
+		}
+	}
+
+	var mismatches []RouteMismatch
+	seen := make(map[routeKey]bool)
+	for _, rt := range runtime {
+		key := routeKey{strings.ToUpper(rt.Method), rt.Path}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		route, ok := staticRoutes[key]
+		if !ok {
+			mismatches = append(mismatches, RouteMismatch{
+				Kind:           MismatchMissingStatic,
+				Method:         key.method,
+				Path:           key.path,
+				RuntimeHandler: rt.Handler,
+			})
+			continue
+		}
+		if !sameHandler(route.HandlerName, rt.Handler) && (route.Registered == "" || !sameHandler(route.Registered, rt.Handler)) {
+			mismatches = append(mismatches, RouteMismatch{
+				Kind:           MismatchHandler,
+				Method:         key.method,
+				Path:           key.path,
+				StaticHandler:  route.HandlerName,
+				RuntimeHandler: rt.Handler,
+				Position:       route.Position,
+			})
+		}
+	}
+
+	for key, route := range staticRoutes {
+		if seen[key] {
+			continue
+		}
+		// Any 只要有任一 method 在執行期出現即視為已註冊，其餘 method 可能被更具體的路由覆蓋
+		if strings.EqualFold(route.Method, "Any") && anyMethodSeen(seen, route.Path) {
+			continue
+		}
+		mismatches = append(mismatches, RouteMismatch{
+			Kind:          MismatchMissingRuntime,
+			Method:        key.method,
+			Path:          key.path,
+			StaticHandler: route.HandlerName,
+			Position:      route.Position,
+		})
+	}
+
+	sort.Slice(mismatches, func(i, j int) bool {
+		a, b := mismatches[i], mismatches[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Method != b.Method {
+			return a.Method < b.Method
+		}
+		return a.Kind < b.Kind
+	})
+	return mismatches
+}
+
+func anyMethodSeen(seen map[routeKey]bool, path string) bool {
+	for key := range seen {
+		if key.path == path {
+			return true
+		}
+	}
+	return false
+}
+
+// sameHandler 比對靜態的 handler 名稱與 runtime.FuncForPC 的名稱，例如
+// handlers.UserHandler.Get 與 github.com/acme/app/handlers.(*UserHandler).Get-fm；
+// 工廠函數回傳的閉包在執行期為 pkg.MakeHandler.func1，因此也會以 RouteInfo.Registered 比對
+func sameHandler(static, runtime string) bool {
+	name := normalizeRuntimeHandler(runtime)
+	return name == static || strings.HasPrefix(name, static+".func")
+}
+
+// normalizeRuntimeHandler 把 runtime 的函數名稱轉成 package 名稱開頭的靜態格式
+func normalizeRuntimeHandler(name string) string {
+	name = strings.TrimSuffix(name, "-fm")
+	if slash := strings.LastIndex(name, "/"); slash >= 0 {
+		name = name[slash+1:]
+	}
+	name = strings.NewReplacer("(*", "", "(", "", ")", "", "[...]", "").Replace(name)
+	return name
+}
@@ -0,0 +1,182 @@
+package swaggo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadRuntimeRoutes(t *testing.T) {
+	log := `[GIN-debug] [WARNING] Running in "debug" mode. Switch to "release" mode in production.
+[GIN-debug] GET    /users                    --> github.com/acme/app/handlers.(*UserHandler).List-fm (3 handlers)
+[GIN-debug] POST   /users/:id/avatar         --> github.com/acme/app/handlers.UploadAvatar (4 handlers)
+[GIN-debug] Listening and serving HTTP on :8080
+`
+	routes, err := ReadRuntimeRoutes(strings.NewReader(log))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []RuntimeRoute{
+		{"GET", "/users", "github.com/acme/app/handlers.(*UserHandler).List-fm"},
+		{"POST", "/users/:id/avatar", "github.com/acme/app/handlers.UploadAvatar"},
+	}
+	if len(routes) != len(want) {
+		t.Fatalf("got %v, want %v", routes, want)
+	}
+	for i := range want {
+		if routes[i] != want[i] {
+			t.Errorf("route %d = %v, want %v", i, routes[i], want[i])
+		}
+	}
+
+	routes, err = ReadRuntimeRoutes(strings.NewReader(`[{"Method":"GET","Path":"/health","Handler":"main.Health"}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(routes) != 1 || routes[0] != (RuntimeRoute{"GET", "/health", "main.Health"}) {
+		t.Errorf("JSON routes = %v", routes)
+	}
+}
+
+func TestCompareRoutes(t *testing.T) {
+	p := analyzeSource(t, `package main
+
+import "github.com/gin-gonic/gin"
+
+type UserHandler struct{}
+
+func (h *UserHandler) List(c *gin.Context) {}
+func (h *UserHandler) Get(c *gin.Context)  {}
+
+func Health(c *gin.Context) {}
+func Legacy(c *gin.Context) {}
+
+func MakeHandler() gin.HandlerFunc {
+	return func(c *gin.Context) { c.JSON(200, nil) }
+}
+
+func main() {
+	r := gin.Default()
+	h := &UserHandler{}
+	r.GET("/users", h.List)
+	r.GET("/users/:id", h.List)
+	r.GET("/greet", MakeHandler())
+	r.Any("/health", Health)
+	r.GET("/legacy", Legacy)
+}
+`)
+
+	runtime := []RuntimeRoute{
+		{"GET", "/users", "main.(*UserHandler).List-fm"},
+		{"GET", "/users/:id", "main.(*UserHandler).Get-fm"},
+		{"GET", "/greet", "main.MakeHandler.func1"},
+		{"GET", "/health", "main.Health"},
+		{"POST", "/health", "main.Health"},
+		{"GET", "/dynamic/:name", "main.main.func1"},
+	}
+	got := make(map[string]string)
+	for _, m := range CompareRoutes(p.Routes, runtime) {
+		got[m.Method+" "+m.Path] = m.Kind
+	}
+	want := map[string]string{
+		"GET /users/:id":     MismatchHandler,
+		"GET /dynamic/:name": MismatchMissingStatic,
+		"GET /legacy":        MismatchMissingRuntime,
+	}
+	if len(got) != len(want) {
+		t.Errorf("mismatches = %v, want %v", got, want)
+	}
+	for key, kind := range want {
+		if got[key] != kind {
+			t.Errorf("%s: got %q, want %q", key, got[key], kind)
+		}
+	}
+}
+
+func TestCompareRoutesRegisteredFunc(t *testing.T) {
+	p := analyzeSource(t, `package main
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type User struct {
+	ID int `+"`json:\"id\"`"+`
+}
+
+type GetUserRequest struct {
+	ID int `+"`uri:\"id\"`"+`
+}
+
+func MakeHandler[T any]() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var v T
+		c.JSON(200, v)
+	}
+}
+
+func named(c *gin.Context) { c.JSON(200, nil) }
+
+func MakeNamed() gin.HandlerFunc {
+	return named
+}
+
+func Typed[Req, Resp any](fn func(context.Context, Req) (Resp, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req Req
+		_ = c.ShouldBind(&req)
+		resp, _ := fn(c, req)
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+type H struct{}
+
+func (h *H) GetUser(ctx context.Context, req GetUserRequest) (*User, error) { return nil, nil }
+
+func legacyCreate(w http.ResponseWriter, r *http.Request) {}
+
+func main() {
+	r := gin.Default()
+	h := &H{}
+	r.GET("/users", MakeHandler[User]())
+	r.GET("/named", MakeNamed())
+	r.GET("/users/:id", Typed(h.GetUser))
+	r.POST("/legacy", gin.WrapF(legacyCreate))
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+}
+`)
+
+	runtime := []RuntimeRoute{
+		{"GET", "/users", "main.MakeHandler[...].func1"},
+		{"GET", "/named", "main.named"},
+		{"GET", "/users/:id", "main.Typed[...].func1"},
+		{"POST", "/legacy", "github.com/gin-gonic/gin.WrapF.func1"},
+		{"GET", "/metrics", "github.com/gin-gonic/gin.WrapH.func1"},
+	}
+	if mismatches := CompareRoutes(p.Routes, runtime); len(mismatches) > 0 {
+		t.Errorf("unexpected mismatches: %v", mismatches)
+	}
+
+	runtime[0].Handler = "main.Other"
+	if mismatches := CompareRoutes(p.Routes, runtime); len(mismatches) != 1 || mismatches[0].Kind != MismatchHandler {
+		t.Errorf("expected one handler mismatch, got %v", mismatches)
+	}
+}
+
+func TestNormalizeRuntimeHandler(t *testing.T) {
+	tests := map[string]string{
+		"github.com/acme/app/handlers.(*UserHandler).Get-fm": "handlers.UserHandler.Get",
+		"github.com/acme/app/handlers.UserHandler.Get-fm":    "handlers.UserHandler.Get",
+		"main.Health": "main.Health",
+		"github.com/acme/app/api.Typed[...].func1": "api.Typed.func1",
+	}
+	for in, want := range tests {
+		if got := normalizeRuntimeHandler(in); got != want {
+			t.Errorf("normalizeRuntimeHandler(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package swaggo
+
+import (
+	"testing"
+)
+
+func TestGinWrappedNetHTTPHandlers(t *testing.T) {
+	src := `package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type LegacyRequest struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type LegacyResponse struct {
+	ID int ` + "`json:\"id\"`" + `
+}
+
+func legacyCreate(w http.ResponseWriter, r *http.Request) {
+	_ = r.URL.Query().Get("dry_run")
+	var req LegacyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(LegacyResponse{})
+}
+
+func main() {
+	r := gin.Default()
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	r.POST("/legacy", gin.WrapF(legacyCreate))
+	r.GET("/debug/pprof/", gin.WrapF(pprof.Index))
+	r.GET("/static/*filepath", gin.WrapH(http.FileServer(http.Dir("./static"))))
+}
+`
+	p := analyzeSource(t, src)
+
+	legacy := findRoute(p, "POST", "/legacy")
+	if legacy == nil || legacy.Handler == nil {
+		for _, route := range p.Routes {
+			t.Logf("  found: %s %s -> %s", route.Method, route.Path, route.HandlerName)
+		}
+		t.Fatal("expected POST /legacy bound to the wrapped handler")
+	}
+	if legacy.HandlerName != "main.legacyCreate" {
+		t.Errorf("handler = %q, want main.legacyCreate", legacy.HandlerName)
+	}
+	if legacy.Handler.RequestBody == nil || legacy.Handler.RequestBody.Name != "LegacyRequest" {
+		t.Errorf("expected LegacyRequest body, got %+v", legacy.Handler.RequestBody)
+	}
+	var hasQuery bool
+	for _, param := range legacy.Handler.Parameters {
+		if param.In == "query" && param.Name == "dry_run" {
+			hasQuery = true
+		}
+	}
+	if !hasQuery {
+		t.Errorf("expected query param dry_run, got %+v", legacy.Handler.Parameters)
+	}
+	if resp := legacy.Handler.Responses[201]; resp == nil || resp.Type == nil || resp.Type.Name != "LegacyResponse" {
+		t.Errorf("expected 201 LegacyResponse, got %+v", legacy.Handler.Responses)
+	}
+	if legacy.Handler.Responses[400] == nil {
+		t.Errorf("expected 400 from http.Error, got %+v", legacy.Handler.Responses)
+	}
+
+	metrics := findRoute(p, "GET", "/metrics")
+	if metrics == nil || metrics.Handler == nil || metrics.HandlerName != "promhttp.Handler" {
+		t.Fatalf("expected /metrics bound to promhttp.Handler, got %+v", metrics)
+	}
+
+	static := findRoute(p, "GET", "/static/*filepath")
+	if static == nil || static.HandlerName != "http.FileServer" || static.Handler == nil {
+		t.Fatalf("expected /static bound to http.FileServer, got %+v", static)
+	}
+	if len(static.Middlewares) != 0 {
+		t.Errorf("http.FileServer should not be treated as middleware, got %+v", static.Middlewares)
+	}
+
+	gen := New()
+	gen.parser = p
+	spec, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("generate error: %v", err)
+	}
+	op := spec.Paths["/metrics"].Get
+	if op == nil || op.Summary != "Prometheus metrics" || len(op.Tags) != 1 || op.Tags[0] != "metrics" {
+		t.Fatalf("expected documented metrics operation, got %+v", op)
+	}
+	if _, ok := op.Responses["200"].Content["text/plain"]; !ok {
+		t.Errorf("expected text/plain metrics response, got %+v", op.Responses["200"])
+	}
+	if op := spec.Paths["/debug/pprof/"].Get; op == nil || op.OperationID != "pprof_Index" {
+		t.Errorf("expected pprof index operation, got %+v", op)
+	}
+}